@@ -9,6 +9,8 @@ type Address struct {
 	AccuracyType string     `json:"accuracy_type"`
 	Source       string     `json:"source"`
 	Fields       Fields     `json:"fields,omitempty"`
+	// Destinations is set when distances are requested, see GeocodeWithDistances
+	Destinations []DistanceDestination `json:"destinations,omitempty"`
 }
 
 // Components
@@ -42,3 +44,42 @@ type Components struct {
 	PreDirectional  string `json:"predirectional"`
 	Prefix          string `json:"prefix"`
 }
+
+// AddressInput is an address already split into its components, which
+// geocodes more reliably than a single line for data kept in columns.
+// Field names follow Components, PostalCode holds the zip code.
+// Country may be a code or name of the US or Canada; Canadian postal
+// codes are validated and normalized before the lookup.
+type AddressInput struct {
+	Street     string `json:"street,omitempty"`
+	City       string `json:"city,omitempty"`
+	State      string `json:"state,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	County     string `json:"county,omitempty"`
+	Country    string `json:"country,omitempty"`
+	// Province may be set instead of State for Canadian addresses
+	Province string `json:"-"`
+}
+
+// IsEmpty reports whether none of the components are set
+func (a AddressInput) IsEmpty() bool {
+	return a == AddressInput{}
+}
+
+// query returns the non-empty components as request parameters
+func (a AddressInput) query() map[string]string {
+	query := map[string]string{}
+	for k, v := range map[string]string{
+		"street":      a.Street,
+		"city":        a.City,
+		"state":       a.State,
+		"postal_code": a.PostalCode,
+		"county":      a.County,
+		"country":     a.Country,
+	} {
+		if v != "" {
+			query[k] = v
+		}
+	}
+	return query
+}
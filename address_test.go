@@ -0,0 +1,93 @@
+package geocodio_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/strategycomplex/go-geocodio"
+)
+
+func TestGeocodeComponents(t *testing.T) {
+	var query map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = map[string]string{}
+		for k := range r.URL.Query() {
+			query[k] = r.URL.Query().Get(k)
+		}
+		w.Write([]byte(optionsTestResponse))
+	}))
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = gc.GeocodeComponents(geocodio.AddressInput{
+		Street:     "1109 N Highland St",
+		City:       AddressTestOneCity,
+		State:      AddressTestOneState,
+		PostalCode: "22201",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"api_key":     "test-key",
+		"street":      "1109 N Highland St",
+		"city":        AddressTestOneCity,
+		"state":       AddressTestOneState,
+		"postal_code": "22201",
+	}
+	if len(query) != len(expected) {
+		t.Error("Unexpected query parameters", query)
+	}
+	for k, v := range expected {
+		if query[k] != v {
+			t.Errorf("Expected %s=%s but saw %s", k, v, query[k])
+		}
+	}
+
+	_, err = gc.GeocodeComponents(geocodio.AddressInput{})
+	if err != geocodio.ErrAddressIsEmpty {
+		t.Error("Expected error", geocodio.ErrAddressIsEmpty, "but saw", err)
+	}
+}
+
+func TestGeocodeBatchComponents(t *testing.T) {
+	var payload []geocodio.AddressInput
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Error(err)
+		}
+		w.Write([]byte(`{"results": [{
+			"query": {"street": "1109 N Highland St", "city": "Arlington", "state": "VA"},
+			"response": ` + optionsTestResponse + `
+		}]}`))
+	}))
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input := geocodio.AddressInput{Street: "1109 N Highland St", City: AddressTestOneCity, State: AddressTestOneState}
+	resp, err := gc.GeocodeBatchComponents(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(payload) != 1 || payload[0] != input {
+		t.Error("Unexpected payload", payload)
+	}
+	if resp.Results[0].QueryComponents == nil || *resp.Results[0].QueryComponents != input {
+		t.Error("Unexpected query components", resp.Results[0].QueryComponents)
+	}
+	if resp.Results[0].Response.Results[0].Location.Latitude != AddressTestOneLatitude {
+		t.Error("Unexpected location", resp.Results[0].Response.Results[0].Location)
+	}
+}
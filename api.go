@@ -2,9 +2,11 @@ package geocodio
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 
 	// "fmt"
 	"io/ioutil"
@@ -19,88 +21,248 @@ const (
 	MethodGet = "GET"
 	// MethodPost constant
 	MethodPost = "POST"
+	// MethodDelete constant
+	MethodDelete = "DELETE"
 )
 
 type saver interface {
 	SaveDebug(requestedURL, status string, statusCode int, body []byte)
 }
 
-func (g *Geocodio) get(path string, query map[string]string, result saver) error {
-	return g.call(MethodGet, path, nil, query, result)
+func (g *Geocodio) get(ctx context.Context, path string, query map[string]string, result saver) error {
+	return g.call(ctx, MethodGet, path, nil, query, result)
 }
 
-func (g *Geocodio) post(path string, payload interface{}, query map[string]string, result saver) error {
-	return g.call(MethodPost, path, payload, query, result)
+func (g *Geocodio) post(ctx context.Context, path string, payload interface{}, query map[string]string, result saver) error {
+	return g.call(ctx, MethodPost, path, payload, query, result)
 }
 
-func (g *Geocodio) call(method, path string, payload interface{}, query map[string]string, result saver) error {
+func (g *Geocodio) call(ctx context.Context, method, path string, payload interface{}, query map[string]string, result saver) error {
 
-	if strings.Index(path, "/") != 0 {
-		return errors.New("Path must start with a forward slash: ' / ' ")
+	if ctx == nil {
+		ctx = context.Background()
 	}
 
-	rawURL := GeocodioAPIBaseURLv1 + path + "?api_key=" + g.APIKey
-
-	if query != nil {
-		for k, v := range query {
-			rawURL = fmt.Sprintf("%s&%s=%s", rawURL, k, url.QueryEscape(v))
+	var body []byte
+	if payload != nil {
+		var err error
+		body, err = json.Marshal(payload)
+		if err != nil {
+			return err
 		}
 	}
 
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
+	return g.callBody(ctx, method, path, body, "application/json", query, result)
+}
+
+// callBody is like call for payloads that are already encoded
+func (g *Geocodio) callBody(ctx context.Context, method, path string, body []byte, contentType string, query map[string]string, result saver) error {
+
+	u, err := g.requestURL(path, query)
+	if err != nil {
+		return err
+	}
+
+	resp, err := g.do(ctx, method, u, body, contentType)
+	if err != nil {
+		return err
 	}
 
-	u, err := url.Parse(rawURL)
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil
+		return err
+	}
+	result.SaveDebug(u.String(), resp.Status, resp.StatusCode, respBody)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newAPIError(u.String(), resp.Status, resp.StatusCode, resp.Header, respBody)
 	}
 
+	err = json.Unmarshal(respBody, &result)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (g *Geocodio) requestURL(path string, query map[string]string) (*url.URL, error) {
+	if strings.Index(path, "/") != 0 {
+		return nil, errors.New("Path must start with a forward slash: ' / ' ")
+	}
+
+	rawURL := g.apiBaseURL() + path + "?api_key=" + url.QueryEscape(g.APIKey)
+
 	if query != nil {
 		for k, v := range query {
-			if u.Query().Get(k) != "" {
-				u.Query().Set(k, v)
-				continue
-			}
-			u.Query().Add(k, v)
+			rawURL = fmt.Sprintf("%s&%s=%s", rawURL, k, url.QueryEscape(v))
 		}
 	}
 
-	req := http.Request{
-		Method: method,
-		URL:    u,
-		Header: http.Header{},
-	}
+	return url.Parse(rawURL)
+}
+
+// jsonResult adapts a plain response value to the saver interface for
+// endpoints whose results do not carry debug information
+type jsonResult struct {
+	v interface{}
+}
+
+func (r *jsonResult) SaveDebug(requestedURL, status string, statusCode int, body []byte) {}
+
+func (r *jsonResult) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, r.v)
+}
+
+// do sends the request, retrying according to the client's retry policy, and
+// returns the final response whatever its status. The caller must close the
+// response body.
+func (g *Geocodio) do(ctx context.Context, method string, u *url.URL, body []byte, contentType string) (*http.Response, error) {
+	policy := g.retryPolicy
+	idempotent := isIdempotent(method, u.Path)
+
+	for attempt := 1; ; attempt++ {
+		if g.rateLimiter != nil {
+			if _, err := g.rateLimiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := g.send(ctx, method, u, body, contentType)
+
+		if attempt >= policy.MaxAttempts || ctx.Err() != nil {
+			return resp, err
+		}
+
+		var (
+			wait     = policy.backoff(attempt)
+			retryErr = err
+			failed   *http.Response
+		)
 
-	if payload != nil {
-		body, err := json.Marshal(payload)
 		if err != nil {
-			return err
+			// the request may or may not have reached the API
+			if !idempotent {
+				return resp, err
+			}
+		} else {
+			if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+				return resp, nil
+			}
+
+			respBody, _ := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+			apiErr := newAPIError(u.String(), resp.Status, resp.StatusCode, resp.Header, respBody)
+
+			// a throttled request was never processed so it is always safe to repeat
+			if !apiErr.Retryable() || (!idempotent && resp.StatusCode != http.StatusTooManyRequests) {
+				return resp, nil
+			}
+
+			if policy.RespectRetryAfter && apiErr.RetryAfter > 0 {
+				wait = apiErr.RetryAfter
+				if policy.MaxBackoff > 0 && wait > policy.MaxBackoff {
+					wait = policy.MaxBackoff
+				}
+			}
+			retryErr = apiErr
+			failed = resp
 		}
 
-		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		// give up rather than sleeping past the deadline, with the failed
+		// response so the caller handles it like any other
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			if failed != nil {
+				return failed, nil
+			}
+			return nil, retryErr
+		}
+
+		if policy.OnRetry != nil {
+			policy.OnRetry(RetryEvent{
+				Attempt: attempt,
+				Method:  method,
+				URL:     redactURL(u.String()),
+				Wait:    wait,
+				Err:     retryErr,
+			})
+		}
 
-		req.Header.Add("Content-Type", "application/json")
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// headerTimeoutKey marks a context whose requests are only bounded by the
+// client's request timeout until the response headers arrive
+type headerTimeoutKey struct{}
+
+// send makes a single attempt, bounded by the client's request timeout
+func (g *Geocodio) send(ctx context.Context, method string, u *url.URL, body []byte, contentType string) (*http.Response, error) {
+	cancel := context.CancelFunc(func() {})
+	var headerTimer *time.Timer
+	if timeout := g.requestTimeout(); timeout > 0 {
+		if headersOnly, _ := ctx.Value(headerTimeoutKey{}).(bool); headersOnly {
+			ctx, cancel = context.WithCancel(ctx)
+			headerTimer = time.AfterFunc(timeout, cancel)
+		} else {
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+		}
 	}
 
-	resp, err := client.Do(&req)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
 	if err != nil {
-		return err
+		cancel()
+		return nil, err
 	}
+	req.Header.Set("User-Agent", g.userAgentHeader())
 
-	defer resp.Body.Close()
+	if body != nil {
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
+		req.Header.Add("Content-Type", contentType)
 	}
-	result.SaveDebug(u.String(), resp.Status, resp.StatusCode, body)
 
-	err = json.Unmarshal(body, &result)
+	resp, err := g.client().Do(req)
+	if headerTimer != nil && !headerTimer.Stop() {
+		// the timer already cancelled the request
+		if err == nil {
+			resp.Body.Close()
+		}
+		resp, err = nil, &url.Error{Op: method[:1] + strings.ToLower(method[1:]), URL: u.String(), Err: context.DeadlineExceeded}
+	}
 	if err != nil {
-		return err
+		cancel()
+		// transport errors quote the request URL, which holds the API key
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = redactURL(urlErr.URL)
+		}
+		return nil, err
 	}
 
-	return nil
+	// keep the timeout running until the caller is done reading
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
 }
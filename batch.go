@@ -0,0 +1,60 @@
+package geocodio
+
+import (
+	"errors"
+	"strconv"
+)
+
+// ErrInvalidLimit error when a negative result limit is requested
+var ErrInvalidLimit = errors.New("Limit must not be negative")
+
+// BatchOptions are applied to every query of a batch lookup, or to a
+// single lookup with GeocodeWithOptions
+type BatchOptions struct {
+	// Fields are data appends such as FieldTimezone or FieldCensus,
+	// each field counts as an additional lookup
+	Fields FieldSet
+	// Limit caps the number of results returned per query, 0 means no limit
+	Limit int
+	// Country restricts matches to the US or Canada, see NormalizeCountry
+	Country string
+}
+
+// batchQuery validates the options against the client's API version
+func (g *Geocodio) batchQuery(opts BatchOptions) (map[string]string, error) {
+	query, err := opts.query()
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkFieldVersions(opts.Fields); err != nil {
+		return nil, err
+	}
+	return query, nil
+}
+
+func (o BatchOptions) query() (map[string]string, error) {
+	if o.Limit < 0 {
+		return nil, ErrInvalidLimit
+	}
+
+	if err := o.Fields.Validate(); err != nil {
+		return nil, err
+	}
+
+	country, err := validateCountry(o.Country)
+	if err != nil {
+		return nil, err
+	}
+
+	query := map[string]string{}
+	if len(o.Fields) > 0 {
+		query["fields"] = o.Fields.String()
+	}
+	if o.Limit > 0 {
+		query["limit"] = strconv.Itoa(o.Limit)
+	}
+	if country != "" {
+		query["country"] = country
+	}
+	return query, nil
+}
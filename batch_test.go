@@ -0,0 +1,145 @@
+package geocodio_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/strategycomplex/go-geocodio"
+)
+
+func TestGeocodeBatchMap(t *testing.T) {
+	var payload map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Error(err)
+		}
+		w.Write([]byte(`{"results": {
+			"row-2": {"query": "` + AddressTestTwoFull + `", "response": {"results": [{"location": {"lat": 42.36629, "lng": -71.0622}}]}},
+			"row-1": {"query": "` + AddressTestOneFull + `", "response": ` + optionsTestResponse + `}
+		}}`))
+	}))
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := gc.GeocodeBatchMap(map[string]string{
+		"row-1": AddressTestOneFull,
+		"row-2": AddressTestTwoFull,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if payload["row-1"] != AddressTestOneFull || payload["row-2"] != AddressTestTwoFull {
+		t.Error("Unexpected payload", payload)
+	}
+	if results["row-1"].Results[0].Location.Latitude != AddressTestOneLatitude {
+		t.Error("Unexpected result for row-1", results["row-1"])
+	}
+	if results["row-2"].Results[0].Location.Latitude != AddressTestTwoLatitude {
+		t.Error("Unexpected result for row-2", results["row-2"])
+	}
+
+	_, err = gc.GeocodeBatchMap(nil)
+	if err != geocodio.ErrBatchAddressesIsEmpty {
+		t.Error("Expected error", geocodio.ErrBatchAddressesIsEmpty, "but saw", err)
+	}
+}
+
+func TestReverseBatchMap(t *testing.T) {
+	var payload map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Error(err)
+		}
+		w.Write([]byte(`{"results": {
+			"a": {"query": "38.886672,-77.094735", "response": {"results": [{"formatted_address": "` + AddressTestOneFull + `"}]}}
+		}}`))
+	}))
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := gc.ReverseBatchMap(map[string]geocodio.Location{
+		"a": {Latitude: AddressTestOneLatitude, Longitude: AddressTestOneLongitude},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if payload["a"] != "38.886672000,-77.094735000" {
+		t.Error("Unexpected payload", payload)
+	}
+	if results["a"].Results[0].Formatted != AddressTestOneFull {
+		t.Error("Unexpected result", results["a"])
+	}
+
+	_, err = gc.ReverseBatchMap(map[string]geocodio.Location{"zero": {}})
+	if err != geocodio.ErrReverseGecodeMissingLatLng {
+		t.Error("Expected error", geocodio.ErrReverseGecodeMissingLatLng, "but saw", err)
+	}
+}
+
+func TestBatchWithOptions(t *testing.T) {
+	var (
+		fields  string
+		limit   string
+		payload []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields = r.URL.Query().Get("fields")
+		limit = r.URL.Query().Get("limit")
+		payload = nil
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Error(err)
+		}
+		w.Write([]byte(`{"results": [{"query": "q", "response": ` + optionsTestResponse + `}]}`))
+	}))
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := geocodio.BatchOptions{Fields: geocodio.NewFieldSet(geocodio.FieldTimezone, geocodio.FieldCongressionalDistrict), Limit: 1}
+
+	_, err = gc.GeocodeBatchWithOptions(opts, AddressTestOneFull, AddressTestTwoFull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields != "timezone,cd" || limit != "1" {
+		t.Errorf("Unexpected fields %q and limit %q", fields, limit)
+	}
+	if len(payload) != 2 || payload[1] != AddressTestTwoFull {
+		t.Error("Unexpected payload", payload)
+	}
+
+	_, err = gc.ReverseBatchWithOptions(opts,
+		AddressTestOneLatitude, AddressTestOneLongitude,
+		AddressTestTwoLatitude, AddressTestTwoLongitude,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields != "timezone,cd" || limit != "1" {
+		t.Errorf("Unexpected fields %q and limit %q", fields, limit)
+	}
+	expected := []string{"38.886672000,-77.094735000", "42.366290000,-71.062200000"}
+	if len(payload) != 2 || payload[0] != expected[0] || payload[1] != expected[1] {
+		t.Error("Unexpected payload", payload)
+	}
+
+	_, err = gc.GeocodeBatchWithOptions(geocodio.BatchOptions{Limit: -1}, AddressTestOneFull)
+	if err != geocodio.ErrInvalidLimit {
+		t.Error("Expected error", geocodio.ErrInvalidLimit, "but saw", err)
+	}
+}
@@ -0,0 +1,282 @@
+package geocodio
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores raw lookup payloads so they decode exactly like a live
+// response. Implementations must be safe for concurrent use; failures should
+// be reported as misses rather than breaking lookups.
+type Cache interface {
+	// Get returns the value stored for key, if it has not expired
+	Get(key string) ([]byte, bool)
+	// Set stores value for key, a ttl of 0 uses the cache's default
+	Set(key string, value []byte, ttl time.Duration)
+	// Delete removes key from the cache
+	Delete(key string)
+}
+
+// CacheStats counts cache activity
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	// Len is the number of entries currently cached
+	Len int
+}
+
+// MemoryCache is an in-process LRU cache of lookup results, bounded by the
+// number of entries and the time they are kept. It is safe for concurrent use.
+type MemoryCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	entries *list.List
+	index   map[string]*list.Element
+	stats   CacheStats
+}
+
+type memoryCacheEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache keeps up to size entries, each for ttl (0 keeps entries
+// until they are evicted)
+func NewMemoryCache(size int, ttl time.Duration) *MemoryCache {
+	if size < 1 {
+		size = 1
+	}
+	return &MemoryCache{
+		size:    size,
+		ttl:     ttl,
+		entries: list.New(),
+		index:   map[string]*list.Element{},
+	}
+}
+
+// WithCache serves repeated lookups from the cache. Single Geocode,
+// GeocodeComponents and Reverse lookups are cached per query, fields and API
+// version; GeocodeBatch and ReverseBatch only send the queries that are not
+// cached. Batches of address components or keyed by caller IDs, and
+// GeocodeWithDistances, are always sent to the API.
+func WithCache(cache Cache) Option {
+	return func(g *Geocodio) error {
+		if cache == nil {
+			return errors.New("Cache must not be nil")
+		}
+		g.cache = cache
+		return nil
+	}
+}
+
+// Get returns the cached value for key
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.index[key]
+	if ok {
+		entry := element.Value.(*memoryCacheEntry)
+		if entry.expires.IsZero() || time.Now().Before(entry.expires) {
+			c.entries.MoveToFront(element)
+			c.stats.Hits++
+			return entry.value, true
+		}
+		c.remove(element)
+	}
+
+	c.stats.Misses++
+	return nil, false
+}
+
+// Set caches value for key, a ttl of 0 uses the cache's default
+func (c *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ttl <= 0 {
+		ttl = c.ttl
+	}
+
+	entry := &memoryCacheEntry{key: key, value: value}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+
+	if element, ok := c.index[key]; ok {
+		element.Value = entry
+		c.entries.MoveToFront(element)
+		return
+	}
+
+	c.index[key] = c.entries.PushFront(entry)
+
+	for c.entries.Len() > c.size {
+		c.remove(c.entries.Back())
+		c.stats.Evictions++
+	}
+}
+
+// Delete removes key from the cache
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.index[key]; ok {
+		c.remove(element)
+	}
+}
+
+// Stats returns the hit, miss and eviction counters
+func (c *MemoryCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Len = c.entries.Len()
+	return stats
+}
+
+func (c *MemoryCache) remove(element *list.Element) {
+	c.entries.Remove(element)
+	delete(c.index, element.Value.(*memoryCacheEntry).key)
+}
+
+// cacheKey identifies a lookup by API version, endpoint and normalized
+// parameters so equivalent single and batch lookups share entries
+func (g *Geocodio) cacheKey(path string, query map[string]string) string {
+	params := make([]string, 0, len(query))
+	for k, v := range query {
+		switch k {
+		case "fields":
+			fields := fieldSetFromNames([]string{v}).Strings()
+			sort.Strings(fields)
+			v = strings.Join(fields, ",")
+		default:
+			v = strings.ToLower(strings.Join(strings.Fields(v), " "))
+		}
+		if v == "" {
+			continue
+		}
+		params = append(params, k+"="+v)
+	}
+	sort.Strings(params)
+
+	return string(g.APIVersion()) + path + "?" + strings.Join(params, "&")
+}
+
+// lookup makes a single geocode or reverse lookup, served from the cache when possible
+func (g *Geocodio) lookup(ctx context.Context, path string, query map[string]string, resp *GeocodeResult) error {
+	if g.cache == nil {
+		return g.get(ctx, path, query, resp)
+	}
+
+	key := g.cacheKey(path, query)
+	if body, ok := g.cache.Get(key); ok {
+		if err := json.Unmarshal(body, resp); err == nil {
+			resp.SaveDebug("", cachedStatus, http.StatusOK, body)
+			return nil
+		}
+		g.cache.Delete(key)
+	}
+
+	if err := g.get(ctx, path, query, resp); err != nil {
+		return err
+	}
+
+	if len(resp.Results) > 0 {
+		g.cache.Set(key, resp.Debug.RawResponse, 0)
+	}
+	return nil
+}
+
+// batchLookup posts a batch of queries, only sending the ones that are not
+// cached and stitching the cached results back in order
+func (g *Geocodio) batchLookup(ctx context.Context, path string, queries []string, query map[string]string, resp *BatchResponse) error {
+	if g.cache == nil {
+		return g.post(ctx, path, queries, query, resp)
+	}
+
+	var (
+		results = make([]BatchResult, len(queries))
+		keys    = make([]string, len(queries))
+		misses  []int
+	)
+
+	for i := range queries {
+		keys[i] = g.cacheKey(path, withParam(query, "q", queries[i]))
+		if body, ok := g.cache.Get(keys[i]); ok {
+			item := BatchResultItem{}
+			if err := json.Unmarshal(body, &item); err == nil {
+				results[i] = BatchResult{Query: queries[i], Response: item}
+				continue
+			}
+			g.cache.Delete(keys[i])
+		}
+		misses = append(misses, i)
+	}
+
+	if len(misses) == 0 {
+		resp.Results = results
+		resp.SaveDebug("", cachedStatus, http.StatusOK, nil)
+		return nil
+	}
+
+	missed := make([]string, len(misses))
+	for j, i := range misses {
+		missed[j] = queries[i]
+	}
+
+	fetched := BatchResponse{}
+	if err := g.post(ctx, path, missed, query, &fetched); err != nil {
+		return err
+	}
+
+	if len(fetched.Results) != len(misses) {
+		return fmt.Errorf("Expected %d batch results but received %d", len(misses), len(fetched.Results))
+	}
+
+	// keep each item's payload as sent by the API so it decodes identically later
+	raw := struct {
+		Results []struct {
+			Response json.RawMessage `json:"response"`
+		} `json:"results"`
+	}{}
+	if err := json.Unmarshal(fetched.Debug.RawResponse, &raw); err != nil || len(raw.Results) != len(misses) {
+		raw.Results = nil
+	}
+
+	for j, i := range misses {
+		results[i] = fetched.Results[j]
+		if raw.Results != nil && len(fetched.Results[j].Response.Results) > 0 {
+			g.cache.Set(keys[i], raw.Results[j].Response, 0)
+		}
+	}
+
+	resp.Results = results
+	resp.Debug = fetched.Debug
+	return nil
+}
+
+// cachedStatus is reported in Debug.Status for results served from the cache
+const cachedStatus = "200 OK (cached)"
+
+func withParam(query map[string]string, key, value string) map[string]string {
+	params := make(map[string]string, len(query)+1)
+	for k, v := range query {
+		params[k] = v
+	}
+	params[key] = value
+	return params
+}
@@ -0,0 +1,183 @@
+package geocodio_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/strategycomplex/go-geocodio"
+	"github.com/strategycomplex/go-geocodio/geocodiotest"
+)
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := geocodio.NewMemoryCache(2, 0)
+	cache.Set("a", []byte("1"), 0)
+	cache.Set("b", []byte("2"), 0)
+
+	if _, ok := cache.Get("a"); !ok {
+		t.Error("Expected a to be cached")
+	}
+	cache.Set("c", []byte("3"), 0)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Expected b to be evicted")
+	}
+	if value, ok := cache.Get("c"); !ok || string(value) != "3" {
+		t.Error("Expected c to be cached but saw", string(value))
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 2 || stats.Misses != 1 || stats.Evictions != 1 || stats.Len != 2 {
+		t.Error("Unexpected stats", stats)
+	}
+}
+
+func TestMemoryCacheExpires(t *testing.T) {
+	cache := geocodio.NewMemoryCache(10, time.Hour)
+	cache.Set("a", []byte("1"), time.Millisecond)
+	cache.Set("b", []byte("2"), 0)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := cache.Get("a"); ok {
+		t.Error("Expected a to have expired")
+	}
+	if _, ok := cache.Get("b"); !ok {
+		t.Error("Expected b to be cached")
+	}
+	if stats := cache.Stats(); stats.Len != 1 {
+		t.Error("Expected 1 cached entry but saw", stats.Len)
+	}
+}
+
+func TestGeocodeCached(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(optionsTestResponse))
+	}))
+	defer server.Close()
+
+	cache := geocodio.NewMemoryCache(100, time.Hour)
+	gc, err := geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithBaseURL(server.URL), geocodio.WithCache(cache))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := gc.Geocode(AddressTestOneFull); err != nil {
+		t.Fatal(err)
+	}
+	result, err := gc.Geocode("  " + AddressTestOneFull + " ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Results[0].Location.Latitude != AddressTestOneLatitude {
+		t.Errorf("Location latitude %f does not match %f", result.Results[0].Location.Latitude, AddressTestOneLatitude)
+	}
+
+	// a different field set is a different lookup
+	if _, err := gc.GeocodeAndReturnTimezone(AddressTestOneFull); err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 2 {
+		t.Error("Expected 2 requests but saw", requests)
+	}
+	if stats := cache.Stats(); stats.Hits != 1 || stats.Misses != 2 {
+		t.Error("Unexpected stats", stats)
+	}
+}
+
+func TestGeocodeBatchSendsOnlyCacheMisses(t *testing.T) {
+	var (
+		requests int32
+		sent     []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+			t.Error(err)
+		}
+		out := make([]string, len(sent))
+		for i, query := range sent {
+			out[i] = fmt.Sprintf(`{"query": %q, "response": {"results": [{"formatted_address": %q}]}}`, query, query)
+		}
+		w.Write([]byte(`{"results": [` + strings.Join(out, ",") + `]}`))
+	}))
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithBaseURL(server.URL), geocodio.WithCache(geocodio.NewMemoryCache(100, 0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := gc.GeocodeBatch("a", "b"); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := gc.GeocodeBatch("b", "c", "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sent) != 1 || sent[0] != "c" {
+		t.Error("Expected only c to be sent but saw", sent)
+	}
+	for i, query := range []string{"b", "c", "a"} {
+		result := resp.Results[i]
+		if result.Query != query || result.Response.Results[0].Formatted != query {
+			t.Error("Unexpected result", i, result)
+		}
+	}
+
+	atomic.StoreInt32(&requests, 0)
+	if _, err := gc.GeocodeBatch("c", "a"); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 0 {
+		t.Error("Expected no requests but saw", requests)
+	}
+}
+
+func TestCachedMethods(t *testing.T) {
+	server, _ := geocodiotest.NewServerT(t)
+	server.HandleGeocode(AddressTestOneFull, geocodio.Address{Formatted: AddressTestOneFull})
+
+	gc, err := server.Geocodio(geocodio.WithCache(geocodio.NewMemoryCache(100, 0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sent := func(call func()) int {
+		before := len(server.Requests())
+		call()
+		return len(server.Requests()) - before
+	}
+
+	if n := sent(func() { gc.Geocode(AddressTestOneFull) }); n != 1 {
+		t.Fatal("Expected the first Geocode to be sent but saw", n)
+	}
+	for name, call := range map[string]func(){
+		"Geocode":      func() { gc.Geocode(AddressTestOneFull) },
+		"GeocodeBatch": func() { gc.GeocodeBatch(AddressTestOneFull) },
+	} {
+		if n := sent(call); n != 0 {
+			t.Errorf("Expected %s to be served from the cache but saw %d requests", name, n)
+		}
+	}
+
+	for name, call := range map[string]func(){
+		"GeocodeBatchMap": func() { gc.GeocodeBatchMap(map[string]string{"1": AddressTestOneFull}) },
+		"ReverseBatchMap": func() {
+			gc.ReverseBatchMap(map[string]geocodio.Location{"1": {Latitude: AddressTestOneLatitude, Longitude: AddressTestOneLongitude}})
+		},
+		"GeocodeBatchComponents": func() { gc.GeocodeBatchComponents(geocodio.AddressInput{Street: AddressTestOneFull}) },
+	} {
+		if n := sent(call) + sent(call); n != 2 {
+			t.Errorf("Expected %s to always be sent but saw %d requests for 2 calls", name, n)
+		}
+	}
+}
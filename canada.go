@@ -0,0 +1,79 @@
+package geocodio
+
+// Riding is the Canadian federal electoral district
+/*
+"riding": {
+	"code": "35075",
+	"name_english": "Ottawa Centre",
+	"name_french": "Ottawa-Centre",
+	"ocd_id": "ocd-division/country:ca/ed:35075-2013",
+	"year": 2013,
+	"source": "Statistics Canada"
+}
+*/
+type Riding struct {
+	Code        string `json:"code"`
+	NameEnglish string `json:"name_english"`
+	NameFrench  string `json:"name_french"`
+	OCDID       string `json:"ocd_id"`
+	Year        int    `json:"year"`
+	Source      string `json:"source"`
+}
+
+// ProvincialRiding is the Canadian provincial electoral district
+/*
+"provriding": {
+	"name_english": "Ottawa Centre",
+	"name_french": "Ottawa-Centre",
+	"ocd_id": "ocd-division/country:ca/province:on/ed:76-2015",
+	"is_upcoming_provriding": false,
+	"source": "Elections Ontario"
+}
+*/
+type ProvincialRiding struct {
+	NameEnglish          string `json:"name_english"`
+	NameFrench           string `json:"name_french"`
+	OCDID                string `json:"ocd_id"`
+	IsUpcomingProvRiding bool   `json:"is_upcoming_provriding"`
+	Source               string `json:"source"`
+}
+
+// StatCan are the Statistics Canada census geographies
+/*
+"statcan": {
+	"division": {"id": "3506", "name": "Ottawa", "type": "CDR", "type_description": "Census division / Division de recensement"},
+	"consolidated_subdivision": {"id": "3506008", "name": "Ottawa"},
+	"subdivision": {"id": "3506008", "name": "Ottawa", "type": "CV", "type_description": "City / Ville"},
+	"economic_region": "Ottawa",
+	"statistical_area": {"code": "505", "code_description": "CMA or CA", "type": "1", "type_description": "Census subdivision within CMA"},
+	"cma_ca": {"id": "505", "name": "Ottawa - Gatineau (Ontario part / partie de l'Ontario)", "type": "B", "type_description": "Census metropolitan area"},
+	"tract": "5050017.00",
+	"census_year": 2016
+}
+*/
+type StatCan struct {
+	Division                StatCanArea            `json:"division"`
+	ConsolidatedSubdivision StatCanArea            `json:"consolidated_subdivision"`
+	Subdivision             StatCanArea            `json:"subdivision"`
+	EconomicRegion          string                 `json:"economic_region"`
+	StatisticalArea         StatCanStatisticalArea `json:"statistical_area"`
+	CMACA                   StatCanArea            `json:"cma_ca"`
+	Tract                   string                 `json:"tract"`
+	CensusYear              int                    `json:"census_year"`
+}
+
+// StatCanArea is a named Statistics Canada geography
+type StatCanArea struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Type            string `json:"type"`
+	TypeDescription string `json:"type_description"`
+}
+
+// StatCanStatisticalArea classifies a subdivision by its metropolitan influence
+type StatCanStatisticalArea struct {
+	Code            string `json:"code"`
+	CodeDescription string `json:"code_description"`
+	Type            string `json:"type"`
+	TypeDescription string `json:"type_description"`
+}
@@ -0,0 +1,83 @@
+package geocodio_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/strategycomplex/go-geocodio/geocodiotest"
+)
+
+const canadaTestFixtures = `{
+	"geocode": {
+		"300 Wellington St, Ottawa ON": {
+			"results": [{
+				"formatted_address": "300 Wellington St, Ottawa, ON K1A 0A9",
+				"location": {"lat": 45.423594, "lng": -75.700929},
+				"accuracy": 1,
+				"accuracy_type": "rooftop",
+				"fields": {
+					"riding": {"code": "35075", "name_english": "Ottawa Centre", "name_french": "Ottawa-Centre", "ocd_id": "ocd-division/country:ca/ed:35075-2013", "year": 2013, "source": "Statistics Canada"},
+					"provriding": {"name_english": "Ottawa Centre", "name_french": "Ottawa-Centre", "ocd_id": "ocd-division/country:ca/province:on/ed:76-2015", "is_upcoming_provriding": false, "source": "Elections Ontario"},
+					"statcan": {
+						"division": {"id": "3506", "name": "Ottawa", "type": "CDR", "type_description": "Census division"},
+						"subdivision": {"id": "3506008", "name": "Ottawa", "type": "CV", "type_description": "City / Ville"},
+						"economic_region": "Ottawa",
+						"cma_ca": {"id": "505", "name": "Ottawa - Gatineau", "type": "B", "type_description": "Census metropolitan area"},
+						"tract": "5050017.00",
+						"census_year": 2016
+					}
+				}
+			}]
+		}
+	},
+	"reverse": {
+		"45.423594,-75.700929": {
+			"results": [{
+				"formatted_address": "300 Wellington St, Ottawa, ON K1A 0A9",
+				"fields": {
+					"riding": {"code": "35075", "name_english": "Ottawa Centre"}
+				}
+			}]
+		}
+	}
+}`
+
+func TestCanadianFields(t *testing.T) {
+	server, gc := geocodiotest.NewServerT(t, strings.NewReader(canadaTestFixtures))
+
+	result, err := gc.GeocodeAndReturnFederalAndProvincialRidings("300 Wellington St, Ottawa ON")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := result.Results[0].Fields
+	if fields.Riding.Code != "35075" || fields.Riding.Year != 2013 || fields.ProvincialRiding.Source != "Elections Ontario" {
+		t.Error("Unexpected ridings", fields.Riding, fields.ProvincialRiding)
+	}
+	if fields.StatCan.Tract != "" {
+		t.Error("Expected statcan to be omitted", fields.StatCan)
+	}
+
+	result, err = gc.GeocodeAndReturnStatCan("300 Wellington St, Ottawa ON")
+	if err != nil {
+		t.Fatal(err)
+	}
+	statcan := result.Results[0].Fields.StatCan
+	if statcan.Division.ID != "3506" || statcan.CMACA.TypeDescription != "Census metropolitan area" || statcan.CensusYear != 2016 {
+		t.Error("Unexpected statcan", statcan)
+	}
+
+	result, err = gc.ReverseAndReturnRiding(45.423594, -75.700929)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Results[0].Fields.Riding.NameEnglish != "Ottawa Centre" {
+		t.Error("Unexpected riding", result.Results[0].Fields.Riding)
+	}
+
+	requests := server.Requests()
+	for i, want := range []string{"riding,provriding", "statcan", "riding"} {
+		if got := requests[i].Query.Get("fields"); got != want {
+			t.Errorf("Request %d asked for fields %q, expected %q", i, got, want)
+		}
+	}
+}
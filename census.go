@@ -1,17 +1,114 @@
 package geocodio
 
-type CensusResults struct {
-	Census2010 *Census `json:"2010,omitempty"`
-	Census2011 *Census `json:"2011,omitempty"`
-	Census2012 *Census `json:"2012,omitempty"`
-	Census2013 *Census `json:"2013,omitempty"`
-	Census2014 *Census `json:"2014,omitempty"`
-	Census2015 *Census `json:"2015,omitempty"`
-	Census2016 *Census `json:"2016,omitempty"`
-	Census2017 *Census `json:"2017,omitempty"`
-	Census2018 *Census `json:"2018,omitempty"`
-	Census2019 *Census `json:"2019,omitempty"`
-	Census2020 *Census `json:"2020,omitempty"`
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CensusResults are the census geographies of an address keyed by census
+// year. Each year is requested with CensusYear, FieldCensus alone returns
+// the most recent one.
+//
+//	fields := geocodio.NewFieldSet(geocodio.CensusYear(2010), geocodio.CensusYear(2023))
+//	result, err := gc.GeocodeWithFields(address, fields)
+//	for _, census := range result.Results[0].Fields.Census.All() {
+//		fmt.Println(census.Year, census.FullFIPS)
+//	}
+type CensusResults map[int]*Census
+
+// Year returns the census geographies of year, nil if they were not returned
+func (self CensusResults) Year(year int) *Census {
+	return self[year]
+}
+
+// Latest returns the census geographies of the most recent year, nil if
+// none were returned
+func (self CensusResults) Latest() *Census {
+	years := self.Years()
+	if len(years) == 0 {
+		return nil
+	}
+	return self[years[len(years)-1]]
+}
+
+// Years returns the census years in ascending order
+func (self CensusResults) Years() []int {
+	years := make([]int, 0, len(self))
+	for year, census := range self {
+		if census != nil {
+			years = append(years, year)
+		}
+	}
+	sort.Ints(years)
+	return years
+}
+
+// All returns the census geographies in ascending order of year
+func (self CensusResults) All() []*Census {
+	all := make([]*Census, 0, len(self))
+	for _, year := range self.Years() {
+		all = append(all, self[year])
+	}
+	return all
+}
+
+// UnmarshalJSON reads the census object keyed by year. The year of each
+// entry is taken from its key, or from census_year when the key is not a
+// year. An empty array, which the API may return instead of an empty
+// object, is read as no results.
+func (self *CensusResults) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		var list []json.RawMessage
+		if err := json.Unmarshal(data, &list); err != nil {
+			return err
+		}
+		if len(list) > 0 {
+			return errors.New("Census results must be an object keyed by year")
+		}
+		*self = CensusResults{}
+		return nil
+	}
+
+	raw := map[string]*Census{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	results := make(CensusResults, len(raw))
+	for key, census := range raw {
+		if census == nil {
+			continue
+		}
+		year, err := strconv.Atoi(strings.TrimPrefix(key, string(FieldCensus)))
+		if err != nil {
+			year = census.Year
+		}
+		if year == 0 {
+			return fmt.Errorf("Census results key %q is not a year", key)
+		}
+		if census.Year == 0 {
+			census.Year = year
+		}
+		results[year] = census
+	}
+	*self = results
+	return nil
+}
+
+// MarshalJSON writes the census object keyed by year, as the API returns it
+func (self CensusResults) MarshalJSON() ([]byte, error) {
+	raw := make(map[string]*Census, len(self))
+	for year, census := range self {
+		if census != nil {
+			raw[strconv.Itoa(year)] = census
+		}
+	}
+	return json.Marshal(raw)
 }
 
 // Census field
@@ -95,24 +192,86 @@ type MedianHouseholdIncome struct {
 	Total CensusDataPoint `json:"Total"`
 }
 
+// HouseholdIncome is the number of households in each income bracket. The
+// brackets are keyed by labels such as "$10,000 to $14,999", which struct
+// tags can't express, so they are read and written by the methods below.
 type HouseholdIncome struct {
 	Meta                 CensusMeta      `json:"meta"`
-	LessThan10000        CensusDataPoint `json:"Less than $10,000"`
-	Income10000to14999   CensusDataPoint `json:"$10,000 to $14,999"`
-	Income15000to19999   CensusDataPoint `json:"$15,000 to $19,999"`
-	Income20000to24999   CensusDataPoint `json:"$20,000 to $24,999"`
-	Income25000to29999   CensusDataPoint `json:"$25,000 to $29,999"`
-	Income30000to34999   CensusDataPoint `json:"$30,000 to $34,999"`
-	Income35000to39999   CensusDataPoint `json:"$35,000 to $39,999"`
-	Income40000to44999   CensusDataPoint `json:"$40,000 to $44,999"`
-	Income45000to49999   CensusDataPoint `json:"$45,000 to $49,999"`
-	Income50000to59000   CensusDataPoint `json:"$50,000 to $59,999"`
-	Income60000to74999   CensusDataPoint `json:"$60,000 to $74,999"`
-	Income75000to99999   CensusDataPoint `json:"$75,000 to $99,999"`
-	Income100000to124999 CensusDataPoint `json:"$100,000 to $124,999"`
-	Income125000to149000 CensusDataPoint `json:"$125,000 to $149,999"`
-	Income150000to199999 CensusDataPoint `json:"$150,000 to $199,999"`
-	Income200000orMore   CensusDataPoint `json:"$200,000 or more"`
+	LessThan10000        CensusDataPoint `json:"-"`
+	Income10000to14999   CensusDataPoint `json:"-"`
+	Income15000to19999   CensusDataPoint `json:"-"`
+	Income20000to24999   CensusDataPoint `json:"-"`
+	Income25000to29999   CensusDataPoint `json:"-"`
+	Income30000to34999   CensusDataPoint `json:"-"`
+	Income35000to39999   CensusDataPoint `json:"-"`
+	Income40000to44999   CensusDataPoint `json:"-"`
+	Income45000to49999   CensusDataPoint `json:"-"`
+	Income50000to59000   CensusDataPoint `json:"-"`
+	Income60000to74999   CensusDataPoint `json:"-"`
+	Income75000to99999   CensusDataPoint `json:"-"`
+	Income100000to124999 CensusDataPoint `json:"-"`
+	Income125000to149000 CensusDataPoint `json:"-"`
+	Income150000to199999 CensusDataPoint `json:"-"`
+	Income200000orMore   CensusDataPoint `json:"-"`
+}
+
+// brackets pairs each income bracket with its label in the API response
+func (h *HouseholdIncome) brackets() []struct {
+	label string
+	point *CensusDataPoint
+} {
+	return []struct {
+		label string
+		point *CensusDataPoint
+	}{
+		{"Less than $10,000", &h.LessThan10000},
+		{"$10,000 to $14,999", &h.Income10000to14999},
+		{"$15,000 to $19,999", &h.Income15000to19999},
+		{"$20,000 to $24,999", &h.Income20000to24999},
+		{"$25,000 to $29,999", &h.Income25000to29999},
+		{"$30,000 to $34,999", &h.Income30000to34999},
+		{"$35,000 to $39,999", &h.Income35000to39999},
+		{"$40,000 to $44,999", &h.Income40000to44999},
+		{"$45,000 to $49,999", &h.Income45000to49999},
+		{"$50,000 to $59,999", &h.Income50000to59000},
+		{"$60,000 to $74,999", &h.Income60000to74999},
+		{"$75,000 to $99,999", &h.Income75000to99999},
+		{"$100,000 to $124,999", &h.Income100000to124999},
+		{"$125,000 to $149,999", &h.Income125000to149000},
+		{"$150,000 to $199,999", &h.Income150000to199999},
+		{"$200,000 or more", &h.Income200000orMore},
+	}
+}
+
+// UnmarshalJSON reads the brackets by their labels
+func (h *HouseholdIncome) UnmarshalJSON(data []byte) error {
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if meta, ok := raw["meta"]; ok {
+		if err := json.Unmarshal(meta, &h.Meta); err != nil {
+			return err
+		}
+	}
+	for _, bracket := range h.brackets() {
+		if value, ok := raw[bracket.label]; ok {
+			if err := json.Unmarshal(value, bracket.point); err != nil {
+				return fmt.Errorf("%s: %w", bracket.label, err)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalJSON writes the brackets with their labels
+func (h HouseholdIncome) MarshalJSON() ([]byte, error) {
+	raw := map[string]interface{}{"meta": h.Meta}
+	for _, bracket := range h.brackets() {
+		raw[bracket.label] = *bracket.point
+	}
+	return json.Marshal(raw)
 }
 
 type Demographic struct {
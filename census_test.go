@@ -0,0 +1,133 @@
+package geocodio_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/strategycomplex/go-geocodio"
+	"github.com/strategycomplex/go-geocodio/geocodiotest"
+)
+
+const censusTestFields = `{
+	"census": {
+		"2010": {"census_year": 2010, "state_fips": "51", "county_fips": "51013", "tract_code": "101801", "block_code": "1004", "block_group": "1", "full_fips": "510131018011004", "source": "US Census Bureau"},
+		"2023": {"census_year": 2023, "state_fips": "51", "county_fips": "51013", "tract_code": "101802", "block_code": "1007", "block_group": "1", "full_fips": "510131018021007", "source": "US Census Bureau"},
+		"2020": {"state_fips": "51", "county_fips": "51013", "tract_code": "101802", "block_code": "1007", "block_group": "1", "full_fips": "510131018021007", "source": "US Census Bureau"}
+	},
+	"acs": {
+		"economics": {
+			"Household income": {
+				"meta": {"table_id": "B19001", "universe": "Households"},
+				"Less than $10,000": {"value": 1000, "percentage": 0.05},
+				"$10,000 to $14,999": {"value": 250, "percentage": 0.012},
+				"$200,000 or more": {"value": 5200, "percentage": 0.27}
+			}
+		}
+	}
+}`
+
+func TestCensusResults(t *testing.T) {
+	fields := geocodio.Fields{}
+	if err := json.Unmarshal([]byte(censusTestFields), &fields); err != nil {
+		t.Fatal(err)
+	}
+
+	census := fields.Census
+	if years := census.Years(); !reflect.DeepEqual(years, []int{2010, 2020, 2023}) {
+		t.Errorf("Expected years 2010, 2020, 2023 but saw %v", years)
+	}
+	if latest := census.Latest(); latest == nil || latest.Year != 2023 || latest.FullFIPS != "510131018021007" {
+		t.Errorf("Unexpected latest census %+v", latest)
+	}
+	if census.Year(2015) != nil {
+		t.Error("Expected no 2015 census")
+	}
+
+	// the year is taken from the key when census_year is missing
+	if c := census.Year(2020); c == nil || c.Year != 2020 {
+		t.Errorf("Unexpected 2020 census %+v", c)
+	}
+
+	var years []int
+	for _, c := range census.All() {
+		years = append(years, c.Year)
+	}
+	if !reflect.DeepEqual(years, census.Years()) {
+		t.Errorf("Expected All in order of year but saw %v", years)
+	}
+
+	data, err := json.Marshal(census)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := geocodio.CensusResults{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, census) {
+		t.Errorf("Expected %s to round trip", data)
+	}
+}
+
+func TestCensusResultsEmpty(t *testing.T) {
+	for _, payload := range []string{`{}`, `{"census": {}}`, `{"census": []}`, `{"census": [ ]}`, `{"census": null}`} {
+		fields := geocodio.Fields{}
+		if err := json.Unmarshal([]byte(payload), &fields); err != nil {
+			t.Errorf("%s: %v", payload, err)
+		}
+		if fields.Census.Latest() != nil || len(fields.Census.Years()) != 0 {
+			t.Errorf("%s: expected no census results", payload)
+		}
+	}
+
+	census := geocodio.CensusResults{}
+	if err := json.Unmarshal([]byte(`{"current": {"state_fips": "51"}}`), &census); err == nil {
+		t.Error("Expected an error for a key without a year")
+	}
+}
+
+func TestHouseholdIncome(t *testing.T) {
+	fields := geocodio.Fields{}
+	if err := json.Unmarshal([]byte(censusTestFields), &fields); err != nil {
+		t.Fatal(err)
+	}
+
+	income := fields.ACS.Economics.HouseholdIncome
+	if income.Meta.TableID != "B19001" || income.LessThan10000.Value != 1000 ||
+		income.Income10000to14999.Value != 250 || income.Income200000orMore.Percentage != 0.27 {
+		t.Errorf("Unexpected household income %+v", income)
+	}
+
+	data, err := json.Marshal(income)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"$10,000 to $14,999":{"value":250,"percentage":0.012}`) {
+		t.Errorf("Expected brackets keyed by label but saw %s", data)
+	}
+}
+
+func TestCensusYearField(t *testing.T) {
+	server, gc := geocodiotest.NewServerT(t)
+
+	fields := geocodio.Fields{}
+	if err := json.Unmarshal([]byte(censusTestFields), &fields); err != nil {
+		t.Fatal(err)
+	}
+	server.HandleGeocode(AddressTestOneFull, geocodio.Address{Formatted: "1109 N Highland St, Arlington, VA 22201", Fields: fields})
+
+	result, err := gc.GeocodeWithFields(AddressTestOneFull, geocodio.NewFieldSet(geocodio.CensusYear(2010), geocodio.CensusYear(2023)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields := server.Requests()[0].Query.Get("fields"); fields != "census2010,census2023" {
+		t.Errorf("Expected census2010,census2023 to be requested but saw %q", fields)
+	}
+
+	census := result.Results[0].Fields.Census
+	if years := census.Years(); !reflect.DeepEqual(years, []int{2010, 2023}) {
+		t.Errorf("Expected only the requested years but saw %v", years)
+	}
+}
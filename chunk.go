@@ -0,0 +1,307 @@
+package geocodio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// MaxBatchSize is the largest number of queries the API accepts per batch
+	MaxBatchSize = 10000
+	// DefaultChunkConcurrency is the number of chunks dispatched at once
+	DefaultChunkConcurrency = 2
+)
+
+// ChunkOptions controls how oversized batches are split and dispatched
+type ChunkOptions struct {
+	BatchOptions
+	// ChunkSize is the number of queries per request, defaults to MaxBatchSize
+	ChunkSize int
+	// Concurrency is the number of requests in flight, defaults to DefaultChunkConcurrency
+	Concurrency int
+}
+
+func (o ChunkOptions) chunkSize() int {
+	if o.ChunkSize <= 0 || o.ChunkSize > MaxBatchSize {
+		return MaxBatchSize
+	}
+	return o.ChunkSize
+}
+
+func (o ChunkOptions) concurrency() int {
+	if o.Concurrency <= 0 {
+		return DefaultChunkConcurrency
+	}
+	return o.Concurrency
+}
+
+// ChunkError describes a chunk of a batch that failed
+type ChunkError struct {
+	// Index is the position of the chunk, starting at 0
+	Index int
+	// Offset and Size locate the chunk's queries in the original input,
+	// for keyed batches they index the sorted keys
+	Offset int
+	Size   int
+	// Keys are the caller IDs in the chunk for keyed batches
+	Keys []string
+	Err  error
+}
+
+func (e ChunkError) Error() string {
+	return fmt.Sprintf("chunk %d (queries %d-%d): %v", e.Index, e.Offset, e.Offset+e.Size-1, e.Err)
+}
+
+func (e ChunkError) Unwrap() error {
+	return e.Err
+}
+
+// BatchError is returned by chunked batches when some chunks failed. The
+// results of the other chunks are still returned alongside it.
+type BatchError struct {
+	Chunks []ChunkError
+}
+
+func (e *BatchError) Error() string {
+	messages := make([]string, len(e.Chunks))
+	for i := range e.Chunks {
+		messages[i] = e.Chunks[i].Error()
+	}
+	return fmt.Sprintf("%d batch chunk(s) failed: %s", len(e.Chunks), strings.Join(messages, "; "))
+}
+
+// Unwrap allows errors.Is and errors.As to match the chunk errors
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Chunks))
+	for i := range e.Chunks {
+		errs[i] = e.Chunks[i]
+	}
+	return errs
+}
+
+// runChunks calls fn for each chunk of n items with bounded concurrency and
+// collects the failures in chunk order
+func runChunks(ctx context.Context, n int, opts ChunkOptions, fn func(ctx context.Context, offset, end int) error) error {
+	var (
+		size   = opts.chunkSize()
+		sem    = make(chan struct{}, opts.concurrency())
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed []ChunkError
+	)
+
+	for index, offset := 0, 0; offset < n; index, offset = index+1, offset+size {
+		end := offset + size
+		if end > n {
+			end = n
+		}
+
+		wg.Add(1)
+		go func(index, offset, end int) {
+			defer wg.Done()
+
+			var err error
+			select {
+			case sem <- struct{}{}:
+				err = fn(ctx, offset, end)
+				<-sem
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+
+			if err != nil {
+				mu.Lock()
+				failed = append(failed, ChunkError{Index: index, Offset: offset, Size: end - offset, Err: err})
+				mu.Unlock()
+			}
+		}(index, offset, end)
+	}
+
+	wg.Wait()
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	sort.Slice(failed, func(i, j int) bool { return failed[i].Index < failed[j].Index })
+	return &BatchError{Chunks: failed}
+}
+
+// chunkFailure marks the queries of a failed chunk so positional results stay aligned
+func chunkFailure(results []BatchResult, err error) {
+	for i := range results {
+		results[i].Response.Error = err.Error()
+	}
+}
+
+// GeocodeBatchChunked looks up any number of addresses by splitting them into
+// API sized batches dispatched concurrently. Results are returned in input
+// order; queries of failed chunks carry the error in Response.Error and the
+// failures are reported as a *BatchError.
+func (g *Geocodio) GeocodeBatchChunked(opts ChunkOptions, addresses ...string) (BatchResponse, error) {
+	return g.GeocodeBatchChunkedContext(context.Background(), opts, addresses...)
+}
+
+// GeocodeBatchChunkedContext is like GeocodeBatchChunked but aborts pending chunks when ctx is cancelled
+func (g *Geocodio) GeocodeBatchChunkedContext(ctx context.Context, opts ChunkOptions, addresses ...string) (BatchResponse, error) {
+	resp := BatchResponse{}
+	if len(addresses) == 0 {
+		return resp, ErrBatchAddressesIsEmpty
+	}
+
+	if _, err := g.batchQuery(opts.BatchOptions); err != nil {
+		return resp, err
+	}
+
+	resp.Results = make([]BatchResult, len(addresses))
+	for i := range addresses {
+		resp.Results[i].Query = addresses[i]
+	}
+
+	err := runChunks(ctx, len(addresses), opts, func(ctx context.Context, offset, end int) error {
+		chunk, err := g.GeocodeBatchWithOptionsContext(ctx, opts.BatchOptions, addresses[offset:end]...)
+		if err == nil && len(chunk.Results) != end-offset {
+			err = fmt.Errorf("expected %d results but received %d", end-offset, len(chunk.Results))
+		}
+		if err != nil {
+			chunkFailure(resp.Results[offset:end], err)
+			return err
+		}
+		copy(resp.Results[offset:end], chunk.Results)
+		return nil
+	})
+
+	return resp, err
+}
+
+// ReverseBatchChunked reverse geocodes any number of lat/lng coordinate pairs
+// in API sized batches, see GeocodeBatchChunked
+func (g *Geocodio) ReverseBatchChunked(opts ChunkOptions, latlngs ...float64) (BatchResponse, error) {
+	return g.ReverseBatchChunkedContext(context.Background(), opts, latlngs...)
+}
+
+// ReverseBatchChunkedContext is like ReverseBatchChunked but aborts pending chunks when ctx is cancelled
+func (g *Geocodio) ReverseBatchChunkedContext(ctx context.Context, opts ChunkOptions, latlngs ...float64) (BatchResponse, error) {
+	resp := BatchResponse{}
+	if len(latlngs) == 0 {
+		return resp, ErrReverseBatchMissingCoords
+	}
+
+	if len(latlngs)%2 == 1 {
+		return resp, ErrReverseBatchInvalidCoordsPairs
+	}
+
+	if _, err := g.batchQuery(opts.BatchOptions); err != nil {
+		return resp, err
+	}
+
+	resp.Results = make([]BatchResult, len(latlngs)/2)
+	for i := range resp.Results {
+		resp.Results[i].Query = Location{Latitude: latlngs[i*2], Longitude: latlngs[i*2+1]}.String()
+	}
+
+	err := runChunks(ctx, len(resp.Results), opts, func(ctx context.Context, offset, end int) error {
+		chunk, err := g.ReverseBatchWithOptionsContext(ctx, opts.BatchOptions, latlngs[offset*2:end*2]...)
+		if err == nil && len(chunk.Results) != end-offset {
+			err = fmt.Errorf("expected %d results but received %d", end-offset, len(chunk.Results))
+		}
+		if err != nil {
+			chunkFailure(resp.Results[offset:end], err)
+			return err
+		}
+		copy(resp.Results[offset:end], chunk.Results)
+		return nil
+	})
+
+	return resp, err
+}
+
+// GeocodeBatchMapChunked looks up any number of addresses keyed by caller IDs
+// in API sized batches. Results of successful chunks are returned even when
+// others fail, the failures are reported as a *BatchError listing their keys.
+func (g *Geocodio) GeocodeBatchMapChunked(opts ChunkOptions, addresses map[string]string) (map[string]BatchResultItem, error) {
+	return g.GeocodeBatchMapChunkedContext(context.Background(), opts, addresses)
+}
+
+// GeocodeBatchMapChunkedContext is like GeocodeBatchMapChunked but aborts pending chunks when ctx is cancelled
+func (g *Geocodio) GeocodeBatchMapChunkedContext(ctx context.Context, opts ChunkOptions, addresses map[string]string) (map[string]BatchResultItem, error) {
+	if len(addresses) == 0 {
+		return nil, ErrBatchAddressesIsEmpty
+	}
+
+	keys := sortedKeys(addresses)
+	return g.runKeyedChunks(ctx, keys, opts, func(ctx context.Context, chunkKeys []string) (map[string]BatchResultItem, error) {
+		chunk := make(map[string]string, len(chunkKeys))
+		for _, key := range chunkKeys {
+			chunk[key] = addresses[key]
+		}
+		return g.geocodeBatchMap(ctx, opts.BatchOptions, chunk)
+	})
+}
+
+// ReverseBatchMapChunked reverse geocodes any number of locations keyed by
+// caller IDs in API sized batches, see GeocodeBatchMapChunked
+func (g *Geocodio) ReverseBatchMapChunked(opts ChunkOptions, locations map[string]Location) (map[string]BatchResultItem, error) {
+	return g.ReverseBatchMapChunkedContext(context.Background(), opts, locations)
+}
+
+// ReverseBatchMapChunkedContext is like ReverseBatchMapChunked but aborts pending chunks when ctx is cancelled
+func (g *Geocodio) ReverseBatchMapChunkedContext(ctx context.Context, opts ChunkOptions, locations map[string]Location) (map[string]BatchResultItem, error) {
+	if len(locations) == 0 {
+		return nil, ErrReverseBatchMissingCoords
+	}
+
+	keys := sortedKeys(locations)
+	return g.runKeyedChunks(ctx, keys, opts, func(ctx context.Context, chunkKeys []string) (map[string]BatchResultItem, error) {
+		chunk := make(map[string]Location, len(chunkKeys))
+		for _, key := range chunkKeys {
+			chunk[key] = locations[key]
+		}
+		return g.reverseBatchMap(ctx, opts.BatchOptions, chunk)
+	})
+}
+
+func (g *Geocodio) runKeyedChunks(ctx context.Context, keys []string, opts ChunkOptions, fn func(ctx context.Context, keys []string) (map[string]BatchResultItem, error)) (map[string]BatchResultItem, error) {
+	if _, err := g.batchQuery(opts.BatchOptions); err != nil {
+		return nil, err
+	}
+
+	var (
+		mu      sync.Mutex
+		results = make(map[string]BatchResultItem, len(keys))
+	)
+
+	err := runChunks(ctx, len(keys), opts, func(ctx context.Context, offset, end int) error {
+		chunk, err := fn(ctx, keys[offset:end])
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		for key, item := range chunk {
+			results[key] = item
+		}
+		mu.Unlock()
+		return nil
+	})
+
+	if batchErr, ok := err.(*BatchError); ok {
+		for i := range batchErr.Chunks {
+			chunk := batchErr.Chunks[i]
+			batchErr.Chunks[i].Keys = keys[chunk.Offset : chunk.Offset+chunk.Size]
+		}
+	}
+
+	return results, err
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,160 @@
+package geocodio_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/strategycomplex/go-geocodio"
+)
+
+// newEchoBatchServer answers batch lookups with one result per query whose
+// formatted address is the query itself, and fails any batch containing "fail"
+func newEchoBatchServer(t *testing.T, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+
+		var raw json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+			t.Error(err)
+		}
+		if strings.Contains(string(raw), "fail") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		item := func(query string) string {
+			return fmt.Sprintf(`{"query": %q, "response": {"results": [{"formatted_address": %q}]}}`, query, query)
+		}
+
+		var (
+			list  []string
+			keyed map[string]string
+			out   []string
+		)
+		if json.Unmarshal(raw, &list) == nil {
+			for _, query := range list {
+				out = append(out, item(query))
+			}
+			w.Write([]byte(`{"results": [` + strings.Join(out, ",") + `]}`))
+			return
+		}
+		if err := json.Unmarshal(raw, &keyed); err != nil {
+			t.Error(err)
+		}
+		for key, query := range keyed {
+			out = append(out, fmt.Sprintf("%q: %s", key, item(query)))
+		}
+		w.Write([]byte(`{"results": {` + strings.Join(out, ",") + `}}`))
+	}))
+}
+
+func TestGeocodeBatchChunked(t *testing.T) {
+	var requests int32
+	server := newEchoBatchServer(t, &requests)
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addresses := []string{"a", "b", "c", "fail", "e"}
+	resp, err := gc.GeocodeBatchChunked(geocodio.ChunkOptions{ChunkSize: 2, Concurrency: 3}, addresses...)
+
+	var batchErr *geocodio.BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatal("Expected *BatchError but saw", err)
+	}
+	if len(batchErr.Chunks) != 1 || batchErr.Chunks[0].Index != 1 || batchErr.Chunks[0].Offset != 2 || batchErr.Chunks[0].Size != 2 {
+		t.Error("Unexpected chunk errors", batchErr.Chunks)
+	}
+	if !errors.Is(err, geocodio.ErrServer) {
+		t.Error("Expected error to match", geocodio.ErrServer)
+	}
+	if requests != 3 {
+		t.Error("Expected 3 requests but saw", requests)
+	}
+
+	if len(resp.Results) != len(addresses) {
+		t.Fatal("Expected", len(addresses), "results but saw", len(resp.Results))
+	}
+	for i, address := range addresses {
+		result := resp.Results[i]
+		if result.Query != address {
+			t.Errorf("Result %d has query %q, expected %q", i, result.Query, address)
+		}
+		failed := i == 2 || i == 3
+		if failed != (result.Response.Error != "") {
+			t.Errorf("Result %d has unexpected error %q", i, result.Response.Error)
+		}
+		if !failed && result.Response.Results[0].Formatted != address {
+			t.Errorf("Result %d is out of order: %q", i, result.Response.Results[0].Formatted)
+		}
+	}
+}
+
+func TestReverseBatchChunked(t *testing.T) {
+	var requests int32
+	server := newEchoBatchServer(t, &requests)
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := gc.ReverseBatchChunked(geocodio.ChunkOptions{ChunkSize: 1},
+		AddressTestOneLatitude, AddressTestOneLongitude,
+		AddressTestTwoLatitude, AddressTestTwoLongitude,
+		AddressTestThreeLatitude, AddressTestThreeLongitude,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 3 {
+		t.Error("Expected 3 requests but saw", requests)
+	}
+	if resp.Results[2].Response.Results[0].Formatted != "32.708343000,-117.158124000" {
+		t.Error("Unexpected result", resp.Results[2])
+	}
+}
+
+func TestGeocodeBatchMapChunked(t *testing.T) {
+	var requests int32
+	server := newEchoBatchServer(t, &requests)
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addresses := map[string]string{"1": "a", "2": "b", "3": "fail", "4": "d"}
+	results, err := gc.GeocodeBatchMapChunked(geocodio.ChunkOptions{ChunkSize: 1}, addresses)
+
+	var batchErr *geocodio.BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatal("Expected *BatchError but saw", err)
+	}
+	if len(batchErr.Chunks) != 1 || len(batchErr.Chunks[0].Keys) != 1 || batchErr.Chunks[0].Keys[0] != "3" {
+		t.Error("Unexpected chunk errors", batchErr.Chunks)
+	}
+
+	if len(results) != 3 {
+		t.Error("Expected 3 results but saw", len(results))
+	}
+	for key, address := range addresses {
+		if key == "3" {
+			continue
+		}
+		if results[key].Results[0].Formatted != address {
+			t.Errorf("Result %s is %q, expected %q", key, results[key].Results[0].Formatted, address)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/strategycomplex/go-geocodio"
+)
+
+// record is the outcome of looking up one input
+type record struct {
+	Query   string             `json:"query"`
+	Results []geocodio.Address `json:"results"`
+	Error   string             `json:"error,omitempty"`
+}
+
+// readInputs returns args, or the non-empty lines of file (stdin for "" or "-")
+func readInputs(args []string, file string, stdin io.Reader) ([]string, error) {
+	if len(args) > 0 {
+		if file != "" {
+			return nil, errors.New("Inputs can be given as arguments or -file, not both")
+		}
+		return args, nil
+	}
+
+	r := stdin
+	if file != "" && file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var inputs []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			inputs = append(inputs, line)
+		}
+	}
+	return inputs, scanner.Err()
+}
+
+// parseLocation parses a "lat,lng" coordinate
+func parseLocation(input string) (geocodio.Location, error) {
+	parts := strings.Split(input, ",")
+	if len(parts) != 2 {
+		return geocodio.Location{}, fmt.Errorf("%q is not a lat,lng coordinate", input)
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return geocodio.Location{}, fmt.Errorf("%q has an invalid latitude", input)
+	}
+	lng, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return geocodio.Location{}, fmt.Errorf("%q has an invalid longitude", input)
+	}
+	return geocodio.Location{Latitude: lat, Longitude: lng}, nil
+}
+
+func geocodeEach(ctx context.Context, gc *geocodio.Geocodio, opts geocodio.BatchOptions, addresses []string) []record {
+	records := make([]record, len(addresses))
+	for i, address := range addresses {
+		result, err := gc.GeocodeWithOptionsContext(ctx, address, opts)
+		records[i] = newRecord(address, result.Results, err, opts.Limit)
+	}
+	return records
+}
+
+func reverseEach(ctx context.Context, gc *geocodio.Geocodio, opts geocodio.BatchOptions, inputs []string) ([]record, error) {
+	locations, err := parseLocations(inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]record, len(inputs))
+	for i, location := range locations {
+		result, err := gc.ReverseWithFieldsContext(ctx, location.Latitude, location.Longitude, opts.Fields)
+		records[i] = newRecord(inputs[i], result.Results, err, opts.Limit)
+	}
+	return records, nil
+}
+
+func geocodeBatch(ctx context.Context, gc *geocodio.Geocodio, opts geocodio.ChunkOptions, addresses []string) ([]record, error) {
+	resp, err := gc.GeocodeBatchChunkedContext(ctx, opts, addresses...)
+	return batchRecords(addresses, resp, err)
+}
+
+func reverseBatch(ctx context.Context, gc *geocodio.Geocodio, opts geocodio.ChunkOptions, inputs []string) ([]record, error) {
+	locations, err := parseLocations(inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	latlngs := make([]float64, 0, 2*len(locations))
+	for _, location := range locations {
+		latlngs = append(latlngs, location.Latitude, location.Longitude)
+	}
+
+	resp, err := gc.ReverseBatchChunkedContext(ctx, opts, latlngs...)
+	return batchRecords(inputs, resp, err)
+}
+
+// batchRecords keeps the results of successful chunks, failed chunks already
+// carry their error on each query
+func batchRecords(inputs []string, resp geocodio.BatchResponse, err error) ([]record, error) {
+	var batchErr *geocodio.BatchError
+	if err != nil && !errors.As(err, &batchErr) {
+		return nil, err
+	}
+
+	records := make([]record, len(inputs))
+	for i, input := range inputs {
+		records[i] = record{Query: input}
+		if i >= len(resp.Results) {
+			records[i].Error = "No response"
+			continue
+		}
+		records[i].Results = resp.Results[i].Response.Results
+		records[i].Error = resp.Results[i].Response.Error
+	}
+	return records, nil
+}
+
+func parseLocations(inputs []string) ([]geocodio.Location, error) {
+	locations := make([]geocodio.Location, len(inputs))
+	for i, input := range inputs {
+		location, err := parseLocation(input)
+		if err != nil {
+			return nil, err
+		}
+		locations[i] = location
+	}
+	return locations, nil
+}
+
+func newRecord(query string, results []geocodio.Result, err error, limit int) record {
+	r := record{Query: query}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	for _, result := range results {
+		r.Results = append(r.Results, result.Address)
+	}
+	return r
+}
@@ -0,0 +1,210 @@
+// Command geocodio geocodes addresses and coordinates from the command line.
+//
+//	geocodio geocode [flags] [address ...]
+//	geocodio reverse [flags] [lat,lng ...]
+//	geocodio batch [flags] [input ...]
+//	geocodio fields
+//
+// Inputs are read from the arguments, or one per line from -file or stdin.
+// The API key is read from GEOCODIO_API_KEY.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/strategycomplex/go-geocodio"
+)
+
+const usage = `Usage: geocodio <command> [flags] [inputs ...]
+
+Commands:
+  geocode   geocode addresses, one lookup per address
+  reverse   reverse geocode "lat,lng" coordinates, one lookup per coordinate
+  batch     geocode (or with -reverse, reverse geocode) inputs in batches
+  fields    list the data append fields that can be requested
+
+Inputs are taken from the arguments, or read one per line from -file or stdin.
+The API key is read from the GEOCODIO_API_KEY environment variable.
+
+Run 'geocodio <command> -h' for the flags of a command.
+`
+
+// errFailed is returned when some lookups failed, their errors are in the output
+var errFailed = errors.New("Some lookups failed")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	switch {
+	case err == nil:
+	case errors.Is(err, flag.ErrHelp):
+		os.Exit(2)
+	case errors.Is(err, errFailed):
+		os.Exit(1)
+	default:
+		fmt.Fprintln(os.Stderr, "geocodio:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return flag.ErrHelp
+	}
+
+	command, args := args[0], args[1:]
+	switch command {
+	case "geocode", "reverse", "batch":
+	case "fields":
+		return writeFields(stdout)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return nil
+	default:
+		fmt.Fprintf(stderr, "geocodio: unknown command %q\n\n%s", command, usage)
+		return flag.ErrHelp
+	}
+
+	opts := commandOptions{}
+	flags := opts.flagSet(command, stderr)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	format, err := parseFormat(opts.format)
+	if err != nil {
+		return err
+	}
+
+	fields, err := geocodio.ParseFieldSet(opts.fields)
+	if err != nil {
+		return err
+	}
+
+	inputs, err := readInputs(flags.Args(), opts.file, stdin)
+	if err != nil {
+		return err
+	}
+	if len(inputs) == 0 {
+		return errors.New("No inputs given")
+	}
+
+	gc, err := opts.client()
+	if err != nil {
+		return err
+	}
+
+	if opts.country != "" && (command == "reverse" || opts.reverse) {
+		return errors.New("-country only applies to geocoding")
+	}
+	// fail once up front instead of on every input
+	switch country := geocodio.NormalizeCountry(opts.country); country {
+	case "", geocodio.CountryUS, geocodio.CountryCanada:
+	default:
+		return fmt.Errorf("%w: %q", geocodio.ErrUnsupportedCountry, country)
+	}
+
+	batch := geocodio.BatchOptions{Fields: fields, Limit: opts.limit, Country: opts.country}
+
+	var records []record
+	switch {
+	case command == "geocode":
+		records = geocodeEach(ctx, gc, batch, inputs)
+	case command == "reverse":
+		records, err = reverseEach(ctx, gc, batch, inputs)
+	case opts.reverse:
+		records, err = reverseBatch(ctx, gc, geocodio.ChunkOptions{BatchOptions: batch, ChunkSize: opts.chunkSize}, inputs)
+	default:
+		records, err = geocodeBatch(ctx, gc, geocodio.ChunkOptions{BatchOptions: batch, ChunkSize: opts.chunkSize}, inputs)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := format.write(stdout, records); err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		if r.Error != "" {
+			return errFailed
+		}
+	}
+	return nil
+}
+
+// commandOptions are the flags shared by the lookup commands
+type commandOptions struct {
+	file       string
+	format     string
+	fields     string
+	limit      int
+	country    string
+	apiVersion string
+	baseURL    string
+	timeout    time.Duration
+	retries    int
+	reverse    bool
+	chunkSize  int
+}
+
+func (o *commandOptions) flagSet(command string, output io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	flags.SetOutput(output)
+
+	flags.StringVar(&o.file, "file", "", "read inputs from `path`, one per line (- for stdin)")
+	flags.StringVar(&o.format, "format", "table", "output `format`: "+strings.Join(formatNames, ", "))
+	flags.StringVar(&o.fields, "fields", "", "comma separated data append `fields`, see 'geocodio fields'")
+	flags.IntVar(&o.limit, "limit", 0, "maximum number of results per input, 0 for no limit")
+	flags.StringVar(&o.apiVersion, "api-version", string(geocodio.DefaultAPIVersion), "API `version`")
+	flags.StringVar(&o.baseURL, "base-url", "", "API base `URL`, overrides -api-version")
+	flags.DurationVar(&o.timeout, "timeout", geocodio.DefaultTimeout, "timeout of each request")
+	flags.IntVar(&o.retries, "retries", geocodio.DefaultRetryPolicy().MaxAttempts-1, "retries of failed or rate limited requests")
+
+	if command != "reverse" {
+		flags.StringVar(&o.country, "country", "", "restrict matches to a `country`, US or CA")
+	}
+	if command == "batch" {
+		flags.BoolVar(&o.reverse, "reverse", false, `inputs are "lat,lng" coordinates to reverse geocode`)
+		flags.IntVar(&o.chunkSize, "chunk-size", geocodio.MaxBatchSize, "inputs sent per batch request")
+	}
+
+	flags.Usage = func() {
+		fmt.Fprintf(output, "Usage: geocodio %s [flags] [inputs ...]\n\nFlags:\n", command)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+func (o *commandOptions) client() (*geocodio.Geocodio, error) {
+	version, err := geocodio.ParseAPIVersion(o.apiVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := geocodio.DefaultRetryPolicy()
+	policy.MaxAttempts = o.retries + 1
+
+	opts := []geocodio.Option{
+		geocodio.WithAPIVersion(version),
+		geocodio.WithTimeout(o.timeout),
+		geocodio.WithRetryPolicy(policy),
+		geocodio.WithUserAgent(geocodio.DefaultUserAgent + "-cli"),
+	}
+	if o.baseURL != "" {
+		opts = append(opts, geocodio.WithBaseURL(o.baseURL))
+	}
+
+	return geocodio.NewWithOptions(opts...)
+}
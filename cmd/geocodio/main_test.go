@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/strategycomplex/go-geocodio"
+	"github.com/strategycomplex/go-geocodio/geocodiotest"
+)
+
+const highland = "1109 N Highland St, Arlington VA"
+
+func runTest(t *testing.T, stdin string, args ...string) (string, error) {
+	server := geocodiotest.NewServer()
+	t.Cleanup(server.Close)
+	server.HandleGeocode(highland, geocodio.Address{
+		Formatted:    "1109 N Highland St, Arlington, VA 22201",
+		Location:     geocodio.Location{Latitude: 38.886672, Longitude: -77.094735},
+		Accuracy:     1,
+		AccuracyType: "rooftop",
+		Fields:       geocodio.Fields{Timezone: geocodio.Timezone{Name: "America/New_York"}},
+	})
+	server.HandleReverse(geocodio.Location{Latitude: 38.886672, Longitude: -77.094735}, geocodio.Address{
+		Formatted: "1109 N Highland St, Arlington, VA 22201",
+	})
+	t.Setenv(geocodio.EnvGeocodioAPIKey, geocodiotest.APIKey)
+
+	var stdout, stderr bytes.Buffer
+	args = append(args[:1:1], append([]string{"-base-url", server.URL, "-retries", "0"}, args[1:]...)...)
+	err := run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr)
+	return stdout.String(), err
+}
+
+func TestGeocodeCommandJSON(t *testing.T) {
+	out, err := runTest(t, "", "geocode", "-format", "json", "-fields", "timezone", highland)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var records []record
+	if err := json.Unmarshal([]byte(out), &records); err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Results[0].Fields.Timezone.Name != "America/New_York" {
+		t.Error("Unexpected output", out)
+	}
+}
+
+func TestBatchCommandCSV(t *testing.T) {
+	out, err := runTest(t, highland+"\n\nnowhere\n", "batch", "-format", "csv")
+	if !errors.Is(err, errFailed) {
+		t.Error("Expected error", errFailed, "but saw", err)
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || rows[1][2] != "38.886672" || rows[1][5] != "rooftop" {
+		t.Error("Unexpected rows", rows)
+	}
+	if rows[2][0] != "nowhere" || rows[2][7] != geocodiotest.NoResultsError {
+		t.Error("Expected an error row", rows[2])
+	}
+}
+
+func TestBatchCommandFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "addresses.txt")
+	if err := os.WriteFile(file, []byte(highland+"\n"+highland+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runTest(t, "", "batch", "-format", "csv", "-file", file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || rows[1][2] != "38.886672" || rows[2][2] != "38.886672" {
+		t.Error("Unexpected rows", rows)
+	}
+
+	// a positional argument is an input, not a file
+	if _, err := runTest(t, "", "batch", "-file", file, highland); err == nil {
+		t.Error("Expected an error for both arguments and -file")
+	}
+}
+
+func TestReverseCommandNDJSON(t *testing.T) {
+	out, err := runTest(t, "", "reverse", "-format", "ndjson", "38.886672,-77.094735", "38.886672, -77.094735")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], "1109 N Highland St") {
+		t.Error("Unexpected output", out)
+	}
+
+	_, err = runTest(t, "", "reverse", "not-a-coordinate")
+	if err == nil {
+		t.Error("Expected an error for an invalid coordinate")
+	}
+}
+
+func TestFieldsCommand(t *testing.T) {
+	var stdout bytes.Buffer
+	if err := run(context.Background(), []string{"fields"}, nil, &stdout, &stdout); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout.String(), "stateleg-next") {
+		t.Error("Expected stateleg-next to be listed", stdout.String())
+	}
+}
+
+func TestCountryFlag(t *testing.T) {
+	if _, err := runTest(t, "", "geocode", "-country", "mexico", highland); !errors.Is(err, geocodio.ErrUnsupportedCountry) {
+		t.Error("Expected error", geocodio.ErrUnsupportedCountry, "but saw", err)
+	}
+	if _, err := runTest(t, "38.886672,-77.094735\n", "batch", "-reverse", "-country", "US"); err == nil {
+		t.Error("Expected -country to be rejected when reverse geocoding")
+	}
+}
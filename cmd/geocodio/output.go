@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/strategycomplex/go-geocodio"
+)
+
+// format writes lookup records to the output
+type format struct {
+	name  string
+	write func(w io.Writer, records []record) error
+}
+
+var formats = []format{
+	{"table", writeTable},
+	{"json", writeJSON},
+	{"ndjson", writeNDJSON},
+	{"csv", writeCSV},
+}
+
+var formatNames = func() []string {
+	names := make([]string, len(formats))
+	for i, f := range formats {
+		names[i] = f.name
+	}
+	return names
+}()
+
+func parseFormat(name string) (format, error) {
+	for _, f := range formats {
+		if f.name == name {
+			return f, nil
+		}
+	}
+	return format{}, fmt.Errorf("Unknown format %q", name)
+}
+
+// writeJSON writes all records as one indented array
+func writeJSON(w io.Writer, records []record) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(records)
+}
+
+// writeNDJSON writes one record per line
+func writeNDJSON(w io.Writer, records []record) error {
+	encoder := json.NewEncoder(w)
+	for _, r := range records {
+		if err := encoder.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// columns of the csv and table formats, one row per input with its best result
+var columns = []string{"query", "formatted_address", "latitude", "longitude", "accuracy", "accuracy_type", "source", "error"}
+
+func row(r record) []string {
+	best := geocodio.Address{}
+	if len(r.Results) > 0 {
+		best = r.Results[0]
+	}
+
+	values := []string{r.Query, best.Formatted, "", "", "", best.AccuracyType, best.Source, r.Error}
+	if len(r.Results) > 0 {
+		values[2] = strconv.FormatFloat(best.Location.Latitude, 'f', -1, 64)
+		values[3] = strconv.FormatFloat(best.Location.Longitude, 'f', -1, 64)
+		values[4] = strconv.FormatFloat(best.Accuracy, 'f', -1, 64)
+	}
+	return values
+}
+
+func writeCSV(w io.Writer, records []record) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(columns); err != nil {
+		return err
+	}
+	for _, r := range records {
+		if err := out.Write(row(r)); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+func writeTable(w io.Writer, records []record) error {
+	out := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	writeTableRow(out, columns)
+	for _, r := range records {
+		writeTableRow(out, row(r))
+	}
+	return out.Flush()
+}
+
+func writeTableRow(w io.Writer, values []string) {
+	for i, v := range values {
+		if i > 0 {
+			io.WriteString(w, "\t")
+		}
+		if v == "" {
+			v = "-"
+		}
+		io.WriteString(w, v)
+	}
+	io.WriteString(w, "\n")
+}
+
+// fieldDescriptions explain each field listed by the fields command
+var fieldDescriptions = map[geocodio.Field]string{
+	geocodio.FieldTimezone:                      "timezone, UTC offset and daylight saving time",
+	geocodio.FieldZip4:                          "ZIP+4 code and delivery details",
+	geocodio.FieldCongressionalDistrict:         "congressional districts and legislators (cd118 for a specific congress)",
+	geocodio.FieldStateLegislativeDistricts:     "state house and senate districts",
+	geocodio.FieldStateLegislativeDistrictsNext: "upcoming state legislative districts",
+	geocodio.FieldSchoolDistricts:               "unified, elementary and secondary school districts",
+	geocodio.FieldCensus:                        "census geographies and FIPS codes (census2020 for a specific year)",
+	geocodio.FieldACSDemographics:               "American Community Survey demographics",
+	geocodio.FieldACSEconomics:                  "American Community Survey income",
+	geocodio.FieldACSFamilies:                   "American Community Survey household composition",
+	geocodio.FieldACSHousing:                    "American Community Survey housing",
+	geocodio.FieldACSSocial:                     "American Community Survey education and veteran status",
+	geocodio.FieldRiding:                        "Canadian federal electoral district",
+	geocodio.FieldProvincialRiding:              "Canadian provincial electoral district",
+	geocodio.FieldStatCan:                       "Statistics Canada geographies",
+}
+
+func writeFields(w io.Writer) error {
+	out := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, field := range geocodio.KnownFields() {
+		fmt.Fprintf(out, "%s\t%s\n", field, fieldDescriptions[field])
+	}
+	return out.Flush()
+}
@@ -1,5 +1,12 @@
 package geocodio
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
 // Congressional District field
 /*
 "name": "Congressional District 8",
@@ -120,15 +127,116 @@ type References struct {
 	WikipediaID      string `json:"wikipedia_id"`
 }
 
+// StateLegislativeDistricts field
+/*
+	v1.0 - v1.4 return a single district per chamber:
+		"house": { "name": "State House District 2", "district_number": 2, ... }
+	v1.5+ return every overlapping district:
+		"house": [{ "name": "State House District 2", "district_number": "2", ... }]
+*/
 type StateLegislativeDistricts struct {
-	House  StateLegislativeDistrict `json:"house"`
-	Senate StateLegislativeDistrict `json:"senate"`
+	// House and Senate are the first, most overlapping, district of each chamber
+	House  StateLegislativeDistrict `json:"-"`
+	Senate StateLegislativeDistrict `json:"-"`
+
+	HouseDistricts  []StateLegislativeDistrict `json:"house"`
+	SenateDistricts []StateLegislativeDistrict `json:"senate"`
+}
+
+// UnmarshalJSON accepts both the single district and the list shapes
+func (d *StateLegislativeDistricts) UnmarshalJSON(data []byte) error {
+	raw := struct {
+		House  json.RawMessage `json:"house"`
+		Senate json.RawMessage `json:"senate"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*d = StateLegislativeDistricts{}
+
+	var err error
+	if d.HouseDistricts, err = decodeStateLegislativeDistricts(raw.House); err != nil {
+		return err
+	}
+	if d.SenateDistricts, err = decodeStateLegislativeDistricts(raw.Senate); err != nil {
+		return err
+	}
+
+	if len(d.HouseDistricts) > 0 {
+		d.House = d.HouseDistricts[0]
+	}
+	if len(d.SenateDistricts) > 0 {
+		d.Senate = d.SenateDistricts[0]
+	}
+	return nil
+}
+
+func decodeStateLegislativeDistricts(data json.RawMessage) ([]StateLegislativeDistrict, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	if data[0] == '[' {
+		districts := []StateLegislativeDistrict{}
+		err := json.Unmarshal(data, &districts)
+		return districts, err
+	}
+
+	district := StateLegislativeDistrict{}
+	if err := json.Unmarshal(data, &district); err != nil {
+		return nil, err
+	}
+	return []StateLegislativeDistrict{district}, nil
 }
 
 type StateLegislativeDistrict struct {
-	Name                         string `json:"name"`
-	DistrictNumber               int    `json:"district_number"`
-	OCDID                        string `json:"ocd_id"`
-	IsUpcomingStateLegisDistrict bool   `json:"is_upcoming_state_legislative_district"`
-	Proportion                   string `json:"proportion"`
+	Name string `json:"name"`
+	// DistrictNumber is a string as some states use districts such as "4A"
+	DistrictNumber               string  `json:"district_number"`
+	OCDID                        string  `json:"ocd_id"`
+	IsUpcomingStateLegisDistrict bool    `json:"is_upcoming_state_legislative_district"`
+	Proportion                   float64 `json:"proportion"`
+}
+
+// UnmarshalJSON accepts district numbers and proportions given either as
+// numbers or as strings, which differs between API versions
+func (d *StateLegislativeDistrict) UnmarshalJSON(data []byte) error {
+	type district StateLegislativeDistrict
+	raw := struct {
+		*district
+		DistrictNumber json.RawMessage `json:"district_number"`
+		Proportion     json.RawMessage `json:"proportion"`
+	}{district: (*district)(d)}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	d.DistrictNumber = rawString(raw.DistrictNumber)
+
+	if proportion := rawString(raw.Proportion); proportion != "" {
+		value, err := strconv.ParseFloat(proportion, 64)
+		if err != nil {
+			return fmt.Errorf("Invalid state legislative district proportion %q", proportion)
+		}
+		d.Proportion = value
+	}
+	return nil
+}
+
+// rawString returns a JSON string or number as a string
+func rawString(data json.RawMessage) string {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		return ""
+	}
+	if data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err == nil {
+			return s
+		}
+	}
+	return string(data)
 }
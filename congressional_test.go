@@ -0,0 +1,30 @@
+package geocodio_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/strategycomplex/go-geocodio"
+)
+
+func TestStateLegislativeDistrictsShapes(t *testing.T) {
+	for _, payload := range []string{
+		// v1.0 - v1.4
+		`{"house": {"name": "State House District 47", "district_number": 47, "proportion": "1"},
+		  "senate": {"name": "State Senate District 31", "district_number": 31, "proportion": "1"}}`,
+		// v1.5+
+		`{"house": [{"name": "State House District 47", "district_number": "47", "proportion": 1}],
+		  "senate": [{"name": "State Senate District 31", "district_number": "31", "proportion": 1}]}`,
+	} {
+		districts := geocodio.StateLegislativeDistricts{}
+		if err := json.Unmarshal([]byte(payload), &districts); err != nil {
+			t.Fatal(err)
+		}
+		if districts.House.DistrictNumber != "47" || districts.House.Proportion != 1 || len(districts.HouseDistricts) != 1 {
+			t.Error("Unexpected house district", districts.House)
+		}
+		if districts.Senate.DistrictNumber != "31" || len(districts.SenateDistricts) != 1 {
+			t.Error("Unexpected senate district", districts.Senate)
+		}
+	}
+}
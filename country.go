@@ -0,0 +1,135 @@
+package geocodio
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Countries supported by the API, as ISO 3166-1 alpha-2 codes
+const (
+	CountryUS     = "US"
+	CountryCanada = "CA"
+)
+
+var (
+	// ErrUnsupportedCountry error when a country other than the US or Canada is requested
+	ErrUnsupportedCountry = errors.New("Country must be US or CA")
+	// ErrInvalidPostalCode error when a Canadian postal code is not of the form A1A 1A1
+	ErrInvalidPostalCode = errors.New("Invalid Canadian postal code")
+)
+
+var countryNames = map[string]string{
+	"US":                       CountryUS,
+	"USA":                      CountryUS,
+	"UNITED STATES":            CountryUS,
+	"UNITED STATES OF AMERICA": CountryUS,
+	"CA":                       CountryCanada,
+	"CAN":                      CountryCanada,
+	"CANADA":                   CountryCanada,
+}
+
+// NormalizeCountry returns the ISO code of the US or Canada given a code or
+// name in any case, such as "usa" or "Canada". Other values are returned
+// upper cased, and the empty string as is.
+func NormalizeCountry(country string) string {
+	country = strings.ToUpper(strings.Join(strings.Fields(strings.ReplaceAll(country, ".", "")), " "))
+	if code, ok := countryNames[country]; ok {
+		return code
+	}
+	return country
+}
+
+// validateCountry normalizes country, which may be empty
+func validateCountry(country string) (string, error) {
+	country = NormalizeCountry(country)
+	if country != "" && country != CountryUS && country != CountryCanada {
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedCountry, country)
+	}
+	return country, nil
+}
+
+// canadianPostalCode excludes the letters Canada Post never uses (D, F, I, O,
+// Q, U, and W or Z in the first position)
+var canadianPostalCode = regexp.MustCompile(`^([ABCEGHJ-NPRSTVXY][0-9][ABCEGHJ-NPRSTV-Z])([0-9][ABCEGHJ-NPRSTV-Z][0-9])$`)
+
+// NormalizeCanadianPostalCode returns a Canadian postal code in the canonical
+// "A1A 1A1" form, accepting any case, spacing or a hyphen
+func NormalizeCanadianPostalCode(postalCode string) (string, error) {
+	compact := strings.ToUpper(strings.Join(strings.FieldsFunc(postalCode, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '\t'
+	}), ""))
+
+	parts := canadianPostalCode.FindStringSubmatch(compact)
+	if parts == nil {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPostalCode, postalCode)
+	}
+	return parts[1] + " " + parts[2], nil
+}
+
+// IsCanadianPostalCode reports whether postalCode is a valid Canadian postal code
+func IsCanadianPostalCode(postalCode string) bool {
+	_, err := NormalizeCanadianPostalCode(postalCode)
+	return err == nil
+}
+
+// normalize validates the country and Canadian postal codes before lookup.
+// Province is sent as the state, and a Canadian postal code without a
+// country implies Canada so the address is not matched in the US.
+func (a AddressInput) normalize() (AddressInput, error) {
+	country, err := validateCountry(a.Country)
+	if err != nil {
+		return a, err
+	}
+	a.Country = country
+
+	if a.Province != "" {
+		if a.State != "" && !strings.EqualFold(a.State, a.Province) {
+			return a, errors.New("State and Province must not both be set")
+		}
+		a.State, a.Province = a.Province, ""
+	}
+
+	switch {
+	case a.Country == CountryCanada && a.PostalCode != "":
+		if a.PostalCode, err = NormalizeCanadianPostalCode(a.PostalCode); err != nil {
+			return a, err
+		}
+	case a.Country == "" && IsCanadianPostalCode(a.PostalCode):
+		a.PostalCode, _ = NormalizeCanadianPostalCode(a.PostalCode)
+		a.Country = CountryCanada
+	}
+
+	return a, nil
+}
+
+// IsCanadian reports whether the components are of a Canadian address
+func (c Components) IsCanadian() bool {
+	return c.Country == CountryCanada
+}
+
+// Province is the province or territory of a Canadian address, which the
+// API returns as the state
+func (c Components) Province() string {
+	if c.IsCanadian() {
+		return c.State
+	}
+	return ""
+}
+
+// PostalCode is the zip code of a US address or the postal code of a Canadian one
+func (c Components) PostalCode() string {
+	return c.Zip
+}
+
+// UnmarshalJSON normalizes the country to its ISO code
+func (c *Components) UnmarshalJSON(data []byte) error {
+	type components Components
+	if err := json.Unmarshal(data, (*components)(c)); err != nil {
+		return err
+	}
+	c.Country = NormalizeCountry(c.Country)
+	return nil
+}
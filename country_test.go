@@ -0,0 +1,174 @@
+package geocodio_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/strategycomplex/go-geocodio"
+	"github.com/strategycomplex/go-geocodio/geocodiotest"
+)
+
+const countryTestFixtures = `{
+	"geocode": {
+		"100 Dundas St, London": {
+			"results": [{
+				"address_components": {"number": "100", "street": "Dundas", "suffix": "St", "city": "London", "state": "KY", "zip": "40741", "country": "US"},
+				"formatted_address": "100 Dundas St, London, KY 40741",
+				"location": {"lat": 37.128, "lng": -84.083},
+				"accuracy": 0.8,
+				"accuracy_type": "range_interpolation"
+			}, {
+				"address_components": {"number": "100", "street": "Dundas", "suffix": "St", "city": "London", "state": "ON", "zip": "N6A 1G2", "country": "Canada"},
+				"formatted_address": "100 Dundas St, London, ON N6A 1G2",
+				"location": {"lat": 42.984, "lng": -81.249},
+				"accuracy": 0.7,
+				"accuracy_type": "range_interpolation"
+			}]
+		},
+		"100 Dundas St, London, ON, N6A 1G2, CA": {
+			"results": [{
+				"address_components": {"number": "100", "street": "Dundas", "suffix": "St", "city": "London", "state": "ON", "zip": "N6A 1G2", "country": "CA"},
+				"formatted_address": "100 Dundas St, London, ON N6A 1G2",
+				"location": {"lat": 42.984, "lng": -81.249},
+				"accuracy": 1,
+				"accuracy_type": "rooftop"
+			}]
+		}
+	}
+}`
+
+func TestNormalizeCountry(t *testing.T) {
+	for input, want := range map[string]string{
+		"":                          "",
+		"us":                        geocodio.CountryUS,
+		"U.S.A.":                    geocodio.CountryUS,
+		"United  States of America": geocodio.CountryUS,
+		"ca":                        geocodio.CountryCanada,
+		"Canada":                    geocodio.CountryCanada,
+		"mx":                        "MX",
+	} {
+		if got := geocodio.NormalizeCountry(input); got != want {
+			t.Errorf("NormalizeCountry(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestNormalizeCanadianPostalCode(t *testing.T) {
+	for input, want := range map[string]string{
+		"K1A 0A9":  "K1A 0A9",
+		"k1a0a9":   "K1A 0A9",
+		" k1a-0a9": "K1A 0A9",
+		"n6a  1g2": "N6A 1G2",
+	} {
+		got, err := geocodio.NormalizeCanadianPostalCode(input)
+		if err != nil || got != want {
+			t.Errorf("NormalizeCanadianPostalCode(%q) = %q, %v, want %q", input, got, err, want)
+		}
+	}
+
+	// D, F, I, O, Q and U are never used, nor W or Z first
+	for _, input := range []string{"", "22201", "K1A 0A", "D1A 0A9", "K1O 0A9", "W1A 0A9", "K1A 0A9 1"} {
+		if _, err := geocodio.NormalizeCanadianPostalCode(input); !errors.Is(err, geocodio.ErrInvalidPostalCode) {
+			t.Errorf("NormalizeCanadianPostalCode(%q) error = %v, want ErrInvalidPostalCode", input, err)
+		}
+		if geocodio.IsCanadianPostalCode(input) {
+			t.Errorf("IsCanadianPostalCode(%q) = true", input)
+		}
+	}
+}
+
+func TestGeocodeWithOptionsCountry(t *testing.T) {
+	server, gc := geocodiotest.NewServerT(t, strings.NewReader(countryTestFixtures))
+
+	result, err := gc.GeocodeWithOptions("100 Dundas St, London", geocodio.BatchOptions{Country: "Canada"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 1 || requests[0].Query.Get("country") != geocodio.CountryCanada {
+		t.Fatalf("expected one request with country=CA, got %+v", requests)
+	}
+	if len(result.Results) != 1 {
+		t.Fatalf("expected only the Canadian result, got %d", len(result.Results))
+	}
+
+	components := result.Results[0].Components
+	if !components.IsCanadian() || components.Province() != "ON" || components.PostalCode() != "N6A 1G2" {
+		t.Errorf("unexpected components %+v", components)
+	}
+
+	// without a country both matches are returned, with the country as a code
+	result, err = gc.GeocodeWithOptions("100 Dundas St, London", geocodio.BatchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(result.Results))
+	}
+	if result.Results[0].Components.Province() != "" || result.Results[1].Components.Country != geocodio.CountryCanada {
+		t.Errorf("unexpected countries %+v, %+v", result.Results[0].Components, result.Results[1].Components)
+	}
+}
+
+func TestGeocodeWithOptionsUnsupportedCountry(t *testing.T) {
+	server, gc := geocodiotest.NewServerT(t, strings.NewReader(countryTestFixtures))
+
+	_, err := gc.GeocodeWithOptions("100 Dundas St, London", geocodio.BatchOptions{Country: "Mexico"})
+	if !errors.Is(err, geocodio.ErrUnsupportedCountry) {
+		t.Errorf("expected ErrUnsupportedCountry, got %v", err)
+	}
+
+	_, err = gc.GeocodeBatchWithOptions(geocodio.BatchOptions{Country: "UK"}, "100 Dundas St, London")
+	if !errors.Is(err, geocodio.ErrUnsupportedCountry) {
+		t.Errorf("expected ErrUnsupportedCountry from batch, got %v", err)
+	}
+
+	if requests := server.Requests(); len(requests) != 0 {
+		t.Errorf("expected no requests, got %d", len(requests))
+	}
+}
+
+func TestGeocodeComponentsCanadian(t *testing.T) {
+	server, gc := geocodiotest.NewServerT(t, strings.NewReader(countryTestFixtures))
+
+	// a Canadian postal code implies the country, and Province is sent as the state
+	result, err := gc.GeocodeComponents(geocodio.AddressInput{
+		Street:     "100 Dundas St",
+		City:       "London",
+		Province:   "ON",
+		PostalCode: "n6a1g2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Results[0].Accuracy != 1 {
+		t.Errorf("expected the Canadian match, got %+v", result.Results[0])
+	}
+
+	query := server.Requests()[0].Query
+	for k, want := range map[string]string{"state": "ON", "postal_code": "N6A 1G2", "country": "CA"} {
+		if got := query.Get(k); got != want {
+			t.Errorf("expected %s=%q, got %q", k, want, got)
+		}
+	}
+
+	_, err = gc.GeocodeComponents(geocodio.AddressInput{Street: "100 Dundas St", PostalCode: "N6A", Country: "Canada"})
+	if !errors.Is(err, geocodio.ErrInvalidPostalCode) {
+		t.Errorf("expected ErrInvalidPostalCode, got %v", err)
+	}
+
+	_, err = gc.GeocodeComponents(geocodio.AddressInput{Street: "100 Dundas St", State: "KY", Province: "ON"})
+	if err == nil {
+		t.Error("expected an error when State and Province differ")
+	}
+
+	_, err = gc.GeocodeBatchComponents(
+		geocodio.AddressInput{Street: "100 Dundas St", City: "London", State: "ON", PostalCode: "N6A 1G2", Country: "CA"},
+		geocodio.AddressInput{Street: "100 Dundas St", Country: "France"},
+	)
+	if !errors.Is(err, geocodio.ErrUnsupportedCountry) {
+		t.Errorf("expected ErrUnsupportedCountry from batch, got %v", err)
+	}
+}
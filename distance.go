@@ -0,0 +1,294 @@
+package geocodio
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrDistanceOriginIsEmpty error when no origin is given
+	ErrDistanceOriginIsEmpty = errors.New("At least one distance origin is required")
+	// ErrDistanceDestinationsIsEmpty error when no destination is given
+	ErrDistanceDestinationsIsEmpty = errors.New("At least one distance destination is required")
+)
+
+// DistanceMode is how distances are measured
+type DistanceMode string
+
+const (
+	// DistanceStraightLine measures the great circle distance
+	DistanceStraightLine DistanceMode = "straightline"
+	// DistanceDriving measures the driving route and its duration
+	DistanceDriving DistanceMode = "driving"
+)
+
+// DistanceUnits is the unit distances are sorted and limited by
+type DistanceUnits string
+
+const (
+	DistanceMiles      DistanceUnits = "miles"
+	DistanceKilometers DistanceUnits = "km"
+)
+
+// DistanceOptions are applied to a distance calculation
+type DistanceOptions struct {
+	// Mode defaults to DistanceStraightLine
+	Mode  DistanceMode
+	Units DistanceUnits
+}
+
+func (o DistanceOptions) query(query map[string]string) {
+	if o.Mode != "" {
+		query["mode"] = string(o.Mode)
+	}
+	if o.Units != "" {
+		query["units"] = string(o.Units)
+	}
+}
+
+// Waypoint is an origin or destination, given either as coordinates or as
+// an address to be geocoded by the API
+type Waypoint struct {
+	Address  string
+	Location *Location
+}
+
+// WaypointAt creates a waypoint from coordinates
+func WaypointAt(latitude, longitude float64) Waypoint {
+	return Waypoint{Location: &Location{Latitude: latitude, Longitude: longitude}}
+}
+
+// WaypointAddress creates a waypoint from an address
+func WaypointAddress(address string) Waypoint {
+	return Waypoint{Address: address}
+}
+
+// IsEmpty reports whether neither coordinates nor an address are set
+func (w Waypoint) IsEmpty() bool {
+	return w.Location == nil && w.Address == ""
+}
+
+// String formats the waypoint as sent to the API
+func (w Waypoint) String() string {
+	if w.Location != nil {
+		return w.Location.String()
+	}
+	return w.Address
+}
+
+func waypointStrings(waypoints []Waypoint) ([]string, error) {
+	values := make([]string, len(waypoints))
+	for i := range waypoints {
+		if waypoints[i].IsEmpty() {
+			return nil, ErrAddressIsEmpty
+		}
+		values[i] = waypoints[i].String()
+	}
+	return values, nil
+}
+
+// DistanceWaypoint is an origin as resolved by the API
+type DistanceWaypoint struct {
+	Query    string   `json:"query"`
+	Location Location `json:"location"`
+	ID       string   `json:"id,omitempty"`
+}
+
+// DistanceDestination is a destination and its distance from the origin
+/*
+{
+	"query": "38.9,-77.03",
+	"location": [38.9, -77.03],
+	"distance_miles": 1.2,
+	"distance_km": 1.9,
+	"duration_seconds": 300
+}
+*/
+type DistanceDestination struct {
+	DistanceWaypoint
+	DistanceMiles float64 `json:"distance_miles"`
+	DistanceKm    float64 `json:"distance_km"`
+	// DurationSeconds is only set for DistanceDriving
+	DurationSeconds float64 `json:"duration_seconds,omitempty"`
+}
+
+// Distance returns the distance in the given units
+func (d DistanceDestination) Distance(units DistanceUnits) float64 {
+	if units == DistanceKilometers {
+		return d.DistanceKm
+	}
+	return d.DistanceMiles
+}
+
+// Duration returns the driving time, 0 for straight line distances
+func (d DistanceDestination) Duration() time.Duration {
+	return time.Duration(d.DurationSeconds * float64(time.Second))
+}
+
+// DistanceResult is the distance from one origin to its destinations
+type DistanceResult struct {
+	Origin       DistanceWaypoint      `json:"origin"`
+	Mode         DistanceMode          `json:"mode"`
+	Destinations []DistanceDestination `json:"destinations"`
+	Debug        struct {
+		RawResponse  []byte `json:"-"`
+		RequestedURL string `json:"requested_url"`
+		Status       string `json:"status"`
+		StatusCode   int    `json:"status_code"`
+	} `json:"-"`
+}
+
+func (self *DistanceResult) SaveDebug(requestedURL, status string, statusCode int, body []byte) {
+	self.Debug.RequestedURL = requestedURL
+	self.Debug.Status = status
+	self.Debug.StatusCode = statusCode
+	self.Debug.RawResponse = body
+}
+
+// DistanceMatrixResult holds the distances from every origin to every destination
+type DistanceMatrixResult struct {
+	Mode    DistanceMode     `json:"mode"`
+	Results []DistanceResult `json:"results"`
+	Debug   struct {
+		RawResponse  []byte `json:"-"`
+		RequestedURL string `json:"requested_url"`
+		Status       string `json:"status"`
+		StatusCode   int    `json:"status_code"`
+	} `json:"-"`
+}
+
+func (self *DistanceMatrixResult) SaveDebug(requestedURL, status string, statusCode int, body []byte) {
+	self.Debug.RequestedURL = requestedURL
+	self.Debug.Status = status
+	self.Debug.StatusCode = statusCode
+	self.Debug.RawResponse = body
+}
+
+// distanceQuery adds the destinations as an indexed array parameter
+func distanceQuery(destinations []Waypoint, opts DistanceOptions, query map[string]string) error {
+	if len(destinations) == 0 {
+		return ErrDistanceDestinationsIsEmpty
+	}
+
+	values, err := waypointStrings(destinations)
+	if err != nil {
+		return err
+	}
+
+	for i := range values {
+		query["destinations["+strconv.Itoa(i)+"]"] = values[i]
+	}
+	opts.query(query)
+	return nil
+}
+
+// Distance calculates the distance from an origin to each destination.
+// The distance endpoints require API v1.8 or newer, selected with
+// WithAPIVersion(APIVersion18), otherwise a *VersionError is returned.
+// See: https://www.geocod.io/docs/#distance
+func (g *Geocodio) Distance(origin Waypoint, destinations []Waypoint, opts DistanceOptions) (DistanceResult, error) {
+	return g.DistanceContext(context.Background(), origin, destinations, opts)
+}
+
+// DistanceContext is like Distance but aborts the request when ctx is cancelled
+func (g *Geocodio) DistanceContext(ctx context.Context, origin Waypoint, destinations []Waypoint, opts DistanceOptions) (DistanceResult, error) {
+	resp := DistanceResult{}
+	if err := g.requireVersion("Distance"); err != nil {
+		return resp, err
+	}
+
+	if origin.IsEmpty() {
+		return resp, ErrDistanceOriginIsEmpty
+	}
+
+	query := map[string]string{"origin": origin.String()}
+	if err := distanceQuery(destinations, opts, query); err != nil {
+		return resp, err
+	}
+
+	err := g.get(ctx, "/distance", query, &resp)
+	return resp, err
+}
+
+// DistanceMatrix calculates the distance from every origin to every
+// destination, it requires API v1.8 or newer like Distance
+func (g *Geocodio) DistanceMatrix(origins, destinations []Waypoint, opts DistanceOptions) (DistanceMatrixResult, error) {
+	return g.DistanceMatrixContext(context.Background(), origins, destinations, opts)
+}
+
+// DistanceMatrixContext is like DistanceMatrix but aborts the request when ctx is cancelled
+func (g *Geocodio) DistanceMatrixContext(ctx context.Context, origins, destinations []Waypoint, opts DistanceOptions) (DistanceMatrixResult, error) {
+	resp := DistanceMatrixResult{}
+	if err := g.requireVersion("DistanceMatrix"); err != nil {
+		return resp, err
+	}
+
+	if len(origins) == 0 {
+		return resp, ErrDistanceOriginIsEmpty
+	}
+	if len(destinations) == 0 {
+		return resp, ErrDistanceDestinationsIsEmpty
+	}
+
+	originValues, err := waypointStrings(origins)
+	if err != nil {
+		return resp, err
+	}
+	destinationValues, err := waypointStrings(destinations)
+	if err != nil {
+		return resp, err
+	}
+
+	payload := struct {
+		Origins      []string      `json:"origins"`
+		Destinations []string      `json:"destinations"`
+		Mode         DistanceMode  `json:"mode,omitempty"`
+		Units        DistanceUnits `json:"units,omitempty"`
+	}{originValues, destinationValues, opts.Mode, opts.Units}
+
+	err = g.post(ctx, "/distance-matrix", payload, nil, &resp)
+	return resp, err
+}
+
+// GeocodeWithDistances will geocode and include the distance from each result
+// to the destinations in Address.Destinations, in a single request. It
+// requires API v1.8 or newer like Distance.
+func (g *Geocodio) GeocodeWithDistances(address string, destinations []Waypoint, opts DistanceOptions) (GeocodeResult, error) {
+	return g.GeocodeWithDistancesContext(context.Background(), address, destinations, opts)
+}
+
+// GeocodeWithDistancesContext is like GeocodeWithDistances but aborts the request when ctx is cancelled
+func (g *Geocodio) GeocodeWithDistancesContext(ctx context.Context, address string, destinations []Waypoint, opts DistanceOptions) (GeocodeResult, error) {
+	resp := GeocodeResult{}
+	if err := g.requireVersion("GeocodeWithDistances"); err != nil {
+		return resp, err
+	}
+
+	if address == "" {
+		return resp, ErrAddressIsEmpty
+	}
+
+	query := map[string]string{"q": address}
+	if err := distanceQuery(destinations, DistanceOptions{}, query); err != nil {
+		return resp, err
+	}
+	if opts.Mode != "" {
+		query["distance_mode"] = string(opts.Mode)
+	}
+	if opts.Units != "" {
+		query["distance_units"] = string(opts.Units)
+	}
+
+	err := g.get(ctx, "/geocode", query, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	if len(resp.Results) == 0 {
+		return resp, ErrNoResultsFound
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,195 @@
+package geocodio_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/strategycomplex/go-geocodio"
+)
+
+const distanceTestDestinations = `[
+	{"query": "42.366290000,-71.062200000", "location": [42.36629, -71.0622], "distance_miles": 393.4, "distance_km": 633.1, "duration_seconds": 25200}
+]`
+
+func TestDistance(t *testing.T) {
+	var query map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = map[string]string{}
+		for k := range r.URL.Query() {
+			query[k] = r.URL.Query().Get(k)
+		}
+		w.Write([]byte(`{
+			"origin": {"query": "` + AddressTestOneFull + `", "location": [38.886672, -77.094735]},
+			"mode": "driving",
+			"destinations": ` + distanceTestDestinations + `
+		}`))
+	}))
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(
+		geocodio.WithAPIKey("test-key"),
+		geocodio.WithBaseURL(server.URL),
+		geocodio.WithAPIVersion(geocodio.APIVersion18),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := gc.Distance(
+		geocodio.WaypointAddress(AddressTestOneFull),
+		[]geocodio.Waypoint{geocodio.WaypointAt(AddressTestTwoLatitude, AddressTestTwoLongitude)},
+		geocodio.DistanceOptions{Mode: geocodio.DistanceDriving, Units: geocodio.DistanceKilometers},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if query["origin"] != AddressTestOneFull || query["destinations[0]"] != "42.366290000,-71.062200000" || query["mode"] != "driving" || query["units"] != "km" {
+		t.Error("Unexpected query", query)
+	}
+	if result.Origin.Location.Latitude != AddressTestOneLatitude {
+		t.Error("Unexpected origin", result.Origin)
+	}
+	destination := result.Destinations[0]
+	if destination.Location.Longitude != AddressTestTwoLongitude {
+		t.Error("Unexpected destination", destination)
+	}
+	if destination.Distance(geocodio.DistanceKilometers) != 633.1 || destination.Distance(geocodio.DistanceMiles) != 393.4 {
+		t.Error("Unexpected distance", destination)
+	}
+	if destination.Duration() != 7*time.Hour {
+		t.Error("Unexpected duration", destination.Duration())
+	}
+
+	_, err = gc.Distance(geocodio.WaypointAddress(AddressTestOneFull), nil, geocodio.DistanceOptions{})
+	if err != geocodio.ErrDistanceDestinationsIsEmpty {
+		t.Error("Expected error", geocodio.ErrDistanceDestinationsIsEmpty, "but saw", err)
+	}
+}
+
+func TestDistanceMatrix(t *testing.T) {
+	var payload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/distance-matrix" {
+			t.Error("Unexpected path", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Error(err)
+		}
+		w.Write([]byte(`{"mode": "straightline", "results": [{
+			"origin": {"query": "a", "location": {"lat": 38.886672, "lng": -77.094735}},
+			"destinations": ` + distanceTestDestinations + `
+		}]}`))
+	}))
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(
+		geocodio.WithAPIKey("test-key"),
+		geocodio.WithBaseURL(server.URL),
+		geocodio.WithAPIVersion(geocodio.APIVersion18),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := gc.DistanceMatrix(
+		[]geocodio.Waypoint{geocodio.WaypointAddress(AddressTestOneFull)},
+		[]geocodio.Waypoint{geocodio.WaypointAddress(AddressTestTwoFull)},
+		geocodio.DistanceOptions{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(payload["origins"].([]interface{})) != 1 || payload["destinations"].([]interface{})[0] != AddressTestTwoFull {
+		t.Error("Unexpected payload", payload)
+	}
+	if _, ok := payload["mode"]; ok {
+		t.Error("Expected mode to be omitted", payload)
+	}
+	if result.Results[0].Origin.Location.Latitude != AddressTestOneLatitude || result.Results[0].Destinations[0].DistanceMiles != 393.4 {
+		t.Error("Unexpected result", result.Results)
+	}
+}
+
+func TestGeocodeWithDistances(t *testing.T) {
+	var query map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = map[string]string{}
+		for k := range r.URL.Query() {
+			query[k] = r.URL.Query().Get(k)
+		}
+		w.Write([]byte(`{"results": [{
+			"formatted_address": "` + AddressTestOneFull + `",
+			"location": {"lat": 38.886672, "lng": -77.094735},
+			"destinations": ` + distanceTestDestinations + `
+		}]}`))
+	}))
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(
+		geocodio.WithAPIKey("test-key"),
+		geocodio.WithBaseURL(server.URL),
+		geocodio.WithAPIVersion(geocodio.APIVersion18),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := gc.GeocodeWithDistances(AddressTestOneFull,
+		[]geocodio.Waypoint{geocodio.WaypointAt(AddressTestTwoLatitude, AddressTestTwoLongitude)},
+		geocodio.DistanceOptions{Mode: geocodio.DistanceDriving},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if query["q"] != AddressTestOneFull || query["destinations[0]"] == "" || query["distance_mode"] != "driving" {
+		t.Error("Unexpected query", query)
+	}
+	if len(result.Results[0].Destinations) != 1 || result.Results[0].Destinations[0].DurationSeconds != 25200 {
+		t.Error("Unexpected destinations", result.Results[0].Destinations)
+	}
+}
+
+func TestDistanceRequiresAPIVersion(t *testing.T) {
+	gc, err := geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithAPIVersion(geocodio.APIVersion16))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = gc.Distance(
+		geocodio.WaypointAddress(AddressTestOneFull),
+		[]geocodio.Waypoint{geocodio.WaypointAddress(AddressTestTwoFull)},
+		geocodio.DistanceOptions{},
+	)
+	if !errors.Is(err, geocodio.ErrUnsupportedVersion) {
+		t.Error("Expected error", geocodio.ErrUnsupportedVersion, "but saw", err)
+	}
+
+	var versionErr *geocodio.VersionError
+	if !errors.As(err, &versionErr) || versionErr.Required != geocodio.APIVersion18 || versionErr.Current != geocodio.APIVersion16 {
+		t.Error("Unexpected version error", err)
+	}
+
+	_, err = gc.DistanceMatrix(
+		[]geocodio.Waypoint{geocodio.WaypointAddress(AddressTestOneFull)},
+		[]geocodio.Waypoint{geocodio.WaypointAddress(AddressTestTwoFull)},
+		geocodio.DistanceOptions{},
+	)
+	if !errors.Is(err, geocodio.ErrUnsupportedVersion) {
+		t.Error("Expected error", geocodio.ErrUnsupportedVersion, "from DistanceMatrix but saw", err)
+	}
+
+	_, err = gc.GeocodeWithDistances(AddressTestOneFull,
+		[]geocodio.Waypoint{geocodio.WaypointAddress(AddressTestTwoFull)},
+		geocodio.DistanceOptions{},
+	)
+	if !errors.Is(err, geocodio.ErrUnsupportedVersion) {
+		t.Error("Expected error", geocodio.ErrUnsupportedVersion, "from GeocodeWithDistances but saw", err)
+	}
+}
@@ -0,0 +1,401 @@
+package geocodio
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DefaultEnrichBatchSize is the number of rows EnrichCSV geocodes per batch request
+const DefaultEnrichBatchSize = 1000
+
+// DefaultEnrichErrorColumn is the header of the column EnrichCSV writes row errors to
+const DefaultEnrichErrorColumn = "geocodio_error"
+
+// ErrEnrichAddressColumnMissing is returned when EnrichOptions names neither
+// an address column nor any address component columns
+var ErrEnrichAddressColumnMissing = errors.New("An address column or address component columns are required")
+
+// EnrichColumn is a column EnrichCSV appends to every row, filled from the
+// best result of the row's address
+type EnrichColumn struct {
+	Header string
+	// Fields are the data appends the column needs, requested automatically
+	Fields FieldSet
+	Value  func(address Address) string
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// Columns EnrichCSV can append, use EnrichColumn for anything else in Address or Fields
+var (
+	ColumnLatitude = EnrichColumn{Header: "latitude", Value: func(a Address) string {
+		return formatFloat(a.Location.Latitude)
+	}}
+	ColumnLongitude = EnrichColumn{Header: "longitude", Value: func(a Address) string {
+		return formatFloat(a.Location.Longitude)
+	}}
+	ColumnAccuracy = EnrichColumn{Header: "accuracy", Value: func(a Address) string {
+		return formatFloat(a.Accuracy)
+	}}
+	ColumnAccuracyType = EnrichColumn{Header: "accuracy_type", Value: func(a Address) string {
+		return a.AccuracyType
+	}}
+	ColumnFormattedAddress = EnrichColumn{Header: "formatted_address", Value: func(a Address) string {
+		return a.Formatted
+	}}
+	ColumnSource = EnrichColumn{Header: "source", Value: func(a Address) string {
+		return a.Source
+	}}
+	ColumnTimezone = EnrichColumn{Header: "timezone", Fields: NewFieldSet(FieldTimezone), Value: func(a Address) string {
+		return a.Fields.Timezone.Name
+	}}
+	ColumnZip9 = EnrichColumn{Header: "zip9", Fields: NewFieldSet(FieldZip4), Value: func(a Address) string {
+		if len(a.Fields.Zip4.Zip9) == 0 {
+			return ""
+		}
+		return a.Fields.Zip4.Zip9[0]
+	}}
+	ColumnCongressionalDistrict = EnrichColumn{Header: "congressional_district", Fields: NewFieldSet(FieldCongressionalDistrict), Value: func(a Address) string {
+		if len(a.Fields.CongressionalDistricts) == 0 {
+			return ""
+		}
+		return strconv.Itoa(a.Fields.CongressionalDistricts[0].DistrictNumber)
+	}}
+	ColumnStateHouseDistrict = EnrichColumn{Header: "state_house_district", Fields: NewFieldSet(FieldStateLegislativeDistricts), Value: func(a Address) string {
+		return a.Fields.StateLegislativeDistricts.House.DistrictNumber
+	}}
+	ColumnStateSenateDistrict = EnrichColumn{Header: "state_senate_district", Fields: NewFieldSet(FieldStateLegislativeDistricts), Value: func(a Address) string {
+		return a.Fields.StateLegislativeDistricts.Senate.DistrictNumber
+	}}
+	ColumnUnifiedSchoolDistrict = EnrichColumn{Header: "unified_school_district", Fields: NewFieldSet(FieldSchoolDistricts), Value: func(a Address) string {
+		return a.Fields.SchoolDistricts.Unified.Name
+	}}
+	ColumnRiding = EnrichColumn{Header: "riding", Fields: NewFieldSet(FieldRiding), Value: func(a Address) string {
+		return a.Fields.Riding.NameEnglish
+	}}
+	ColumnProvincialRiding = EnrichColumn{Header: "provincial_riding", Fields: NewFieldSet(FieldProvincialRiding), Value: func(a Address) string {
+		return a.Fields.ProvincialRiding.NameEnglish
+	}}
+)
+
+// DefaultEnrichColumns are appended when EnrichOptions.Columns is empty
+func DefaultEnrichColumns() []EnrichColumn {
+	return []EnrichColumn{
+		ColumnLatitude,
+		ColumnLongitude,
+		ColumnAccuracy,
+		ColumnAccuracyType,
+		ColumnFormattedAddress,
+	}
+}
+
+// AddressColumns names the header of each address component column,
+// empty names are not sent
+type AddressColumns struct {
+	Street     string
+	City       string
+	State      string
+	PostalCode string
+	County     string
+	Country    string
+}
+
+// EnrichOptions configure EnrichCSV
+type EnrichOptions struct {
+	// AddressColumn is the header of a single line address column
+	AddressColumn string
+	// Components are used instead when AddressColumn is empty
+	Components AddressColumns
+	// Columns are appended to every row, DefaultEnrichColumns if empty
+	Columns []EnrichColumn
+	// Fields are requested in addition to those the columns need
+	Fields FieldSet
+	// Country restricts matches to the US or Canada, rows with a country
+	// column are still sent with their own
+	Country string
+	// ErrorColumn is the header of the appended error column,
+	// DefaultEnrichErrorColumn if empty
+	ErrorColumn string
+	// BatchSize is the number of rows per batch request, DefaultEnrichBatchSize
+	// if 0 and at most MaxBatchSize
+	BatchSize int
+	// Comma is the field delimiter of the input and output, ',' if 0
+	Comma rune
+}
+
+// EnrichStats counts the rows EnrichCSV wrote
+type EnrichStats struct {
+	Rows    int
+	Matched int
+	Failed  int
+}
+
+// EnrichCSV reads CSV rows with a header from r, geocodes the address of each
+// row in batches and writes the rows to w with the result columns and an error
+// column appended. Rows that fail, including whole batches, are written with
+// their error; only reading, writing and context errors stop the run.
+func (g *Geocodio) EnrichCSV(r io.Reader, w io.Writer, opts EnrichOptions) (EnrichStats, error) {
+	return g.EnrichCSVContext(context.Background(), r, w, opts)
+}
+
+// EnrichCSVContext is like EnrichCSV but aborts when ctx is cancelled
+func (g *Geocodio) EnrichCSVContext(ctx context.Context, r io.Reader, w io.Writer, opts EnrichOptions) (EnrichStats, error) {
+	stats := EnrichStats{}
+
+	in := csv.NewReader(r)
+	in.FieldsPerRecord = -1
+	out := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		in.Comma = opts.Comma
+		out.Comma = opts.Comma
+	}
+
+	header, err := in.Read()
+	if err == io.EOF {
+		return stats, nil
+	}
+	if err != nil {
+		return stats, err
+	}
+
+	address, singleLine, err := opts.addressOf(header)
+	if err != nil {
+		return stats, err
+	}
+
+	columns := opts.Columns
+	if len(columns) == 0 {
+		columns = DefaultEnrichColumns()
+	}
+
+	batch := BatchOptions{Fields: opts.Fields, Limit: 1, Country: opts.Country}
+	for _, column := range columns {
+		batch.Fields = batch.Fields.Union(column.Fields)
+	}
+	if _, err := g.batchQuery(batch); err != nil {
+		return stats, err
+	}
+
+	errorColumn := opts.ErrorColumn
+	if errorColumn == "" {
+		errorColumn = DefaultEnrichErrorColumn
+	}
+
+	outHeader := append([]string(nil), header...)
+	for _, column := range columns {
+		outHeader = append(outHeader, column.Header)
+	}
+	if err := out.Write(append(outHeader, errorColumn)); err != nil {
+		return stats, err
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultEnrichBatchSize
+	}
+	if batchSize > MaxBatchSize {
+		batchSize = MaxBatchSize
+	}
+
+	rows := make([][]string, 0, batchSize)
+	flush := func() error {
+		if len(rows) == 0 {
+			return nil
+		}
+		results := g.enrichBatch(ctx, batch, rows, address, singleLine)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		for i, row := range rows {
+			// pad short rows so appended columns line up with the header
+			for len(row) < len(header) {
+				row = append(row, "")
+			}
+
+			values := make([]string, len(columns)+1)
+			if results[i].err != nil {
+				values[len(columns)] = results[i].err.Error()
+				stats.Failed++
+			} else {
+				for j, column := range columns {
+					values[j] = column.Value(results[i].address)
+				}
+				stats.Matched++
+			}
+
+			if err := out.Write(append(row, values...)); err != nil {
+				return err
+			}
+			stats.Rows++
+		}
+
+		rows = rows[:0]
+		out.Flush()
+		return out.Error()
+	}
+
+	for {
+		row, err := in.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stats, err
+		}
+
+		rows = append(rows, row)
+		if len(rows) == batchSize {
+			if err := flush(); err != nil {
+				return stats, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return stats, err
+	}
+
+	out.Flush()
+	return stats, out.Error()
+}
+
+// enrichResult is the best result of a row, or why there is none
+type enrichResult struct {
+	address Address
+	err     error
+}
+
+// enrichBatch geocodes the rows with addresses in one request, rows without
+// an address fail without being sent
+func (g *Geocodio) enrichBatch(ctx context.Context, opts BatchOptions, rows [][]string, address func(row []string) AddressInput, singleLine bool) []enrichResult {
+	results := make([]enrichResult, len(rows))
+
+	var (
+		indexes []int
+		inputs  []AddressInput
+	)
+	for i, row := range rows {
+		input := address(row)
+		if input.IsEmpty() {
+			results[i].err = ErrAddressIsEmpty
+			continue
+		}
+		if !singleLine {
+			// an invalid country or postal code fails its row, not the batch
+			var err error
+			if input, err = input.normalize(); err != nil {
+				results[i].err = err
+				continue
+			}
+		}
+		indexes = append(indexes, i)
+		inputs = append(inputs, input)
+	}
+	if len(inputs) == 0 {
+		return results
+	}
+
+	var (
+		resp BatchResponse
+		err  error
+	)
+	if singleLine {
+		addresses := make([]string, len(inputs))
+		for i, input := range inputs {
+			addresses[i] = input.Street
+		}
+		resp, err = g.GeocodeBatchWithOptionsContext(ctx, opts, addresses...)
+	} else {
+		resp, err = g.GeocodeBatchComponentsWithOptionsContext(ctx, opts, inputs...)
+	}
+
+	if err == nil && len(resp.Results) != len(inputs) {
+		err = fmt.Errorf("Expected %d batch results but received %d", len(inputs), len(resp.Results))
+	}
+
+	for j, i := range indexes {
+		switch {
+		case err != nil:
+			results[i].err = err
+		case resp.Results[j].Response.Error != "":
+			results[i].err = errors.New(resp.Results[j].Response.Error)
+		case len(resp.Results[j].Response.Results) == 0:
+			results[i].err = ErrNoResultsFound
+		default:
+			results[i].address = resp.Results[j].Response.Results[0]
+		}
+	}
+	return results
+}
+
+// addressOf resolves the address columns against the header. A single line
+// address is returned in Street, and singleLine is set.
+func (o EnrichOptions) addressOf(header []string) (address func(row []string) AddressInput, singleLine bool, err error) {
+	index := func(name string) (int, error) {
+		if name == "" {
+			return -1, nil
+		}
+		for i, column := range header {
+			if strings.EqualFold(strings.TrimSpace(column), name) {
+				return i, nil
+			}
+		}
+		return -1, fmt.Errorf("Column %q not found in header", name)
+	}
+
+	value := func(row []string, i int) string {
+		if i < 0 || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	if o.AddressColumn != "" {
+		i, err := index(o.AddressColumn)
+		if err != nil {
+			return nil, false, err
+		}
+		return func(row []string) AddressInput {
+			return AddressInput{Street: value(row, i)}
+		}, true, nil
+	}
+
+	if o.Components == (AddressColumns{}) {
+		return nil, false, ErrEnrichAddressColumnMissing
+	}
+
+	var indexes [6]int
+	for k, name := range []string{
+		o.Components.Street,
+		o.Components.City,
+		o.Components.State,
+		o.Components.PostalCode,
+		o.Components.County,
+		o.Components.Country,
+	} {
+		i, err := index(name)
+		if err != nil {
+			return nil, false, err
+		}
+		indexes[k] = i
+	}
+
+	return func(row []string) AddressInput {
+		return AddressInput{
+			Street:     value(row, indexes[0]),
+			City:       value(row, indexes[1]),
+			State:      value(row, indexes[2]),
+			PostalCode: value(row, indexes[3]),
+			County:     value(row, indexes[4]),
+			Country:    value(row, indexes[5]),
+		}
+	}, false, nil
+}
@@ -0,0 +1,123 @@
+package geocodio_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/strategycomplex/go-geocodio"
+	"github.com/strategycomplex/go-geocodio/geocodiotest"
+)
+
+func newEnrichServer(t *testing.T) (*geocodiotest.Server, *geocodio.Geocodio) {
+	server, gc := geocodiotest.NewServerT(t)
+	server.HandleGeocode(AddressTestOneFull, geocodio.Address{
+		Formatted:    AddressTestOneFull,
+		Location:     geocodio.Location{Latitude: AddressTestOneLatitude, Longitude: AddressTestOneLongitude},
+		Accuracy:     1,
+		AccuracyType: "rooftop",
+		Fields:       geocodio.Fields{Timezone: geocodio.Timezone{Name: "America/New_York"}},
+	})
+	return server, gc
+}
+
+func TestEnrichCSV(t *testing.T) {
+	server, gc := newEnrichServer(t)
+
+	input := "id,address\n" +
+		"1," + `"` + AddressTestOneFull + `"` + "\n" +
+		"2,123 Nonsense Ln\n" +
+		"3,\n" +
+		"4," + `"` + AddressTestOneFull + `"` + "\n"
+
+	var out bytes.Buffer
+	stats, err := gc.EnrichCSV(strings.NewReader(input), &out, geocodio.EnrichOptions{
+		AddressColumn: "Address",
+		Columns:       []geocodio.EnrichColumn{geocodio.ColumnLatitude, geocodio.ColumnAccuracyType, geocodio.ColumnTimezone},
+		BatchSize:     2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Rows != 4 || stats.Matched != 2 || stats.Failed != 2 {
+		t.Error("Unexpected stats", stats)
+	}
+
+	rows, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(rows[0], ",") != "id,address,latitude,accuracy_type,timezone,geocodio_error" {
+		t.Error("Unexpected header", rows[0])
+	}
+	if rows[1][2] != "38.886672" || rows[1][3] != "rooftop" || rows[1][4] != "America/New_York" || rows[1][5] != "" {
+		t.Error("Unexpected row", rows[1])
+	}
+	if rows[2][2] != "" || rows[2][5] != geocodiotest.NoResultsError {
+		t.Error("Expected an error row", rows[2])
+	}
+	if rows[3][5] != geocodio.ErrAddressIsEmpty.Error() {
+		t.Error("Expected an empty address error", rows[3])
+	}
+
+	// two batches of two rows, the empty address is never sent
+	requests := server.Requests()
+	if len(requests) != 2 || requests[0].Query.Get("fields") != "timezone" || requests[0].Query.Get("limit") != "1" {
+		t.Error("Unexpected requests", requests)
+	}
+}
+
+func TestEnrichCSVComponents(t *testing.T) {
+	server, gc := newEnrichServer(t)
+
+	input := "street;city;state;zip\n1109 N Highland St;Arlington;VA;22201\n"
+
+	var out bytes.Buffer
+	_, err := gc.EnrichCSV(strings.NewReader(input), &out, geocodio.EnrichOptions{
+		Components: geocodio.AddressColumns{Street: "street", City: "city", State: "state", PostalCode: "zip"},
+		Comma:      ';',
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(out.String(), "1109 N Highland St;Arlington;VA;22201;38.886672;-77.094735;1;rooftop;") {
+		t.Error("Unexpected output", out.String())
+	}
+	if body := string(server.Requests()[0].Body); !strings.Contains(body, `"city":"Arlington"`) {
+		t.Error("Expected components to be sent but saw", body)
+	}
+}
+
+func TestEnrichCSVMissingColumn(t *testing.T) {
+	_, gc := newEnrichServer(t)
+
+	var out bytes.Buffer
+	_, err := gc.EnrichCSV(strings.NewReader("id,street\n"), &out, geocodio.EnrichOptions{AddressColumn: "address"})
+	if err == nil {
+		t.Error("Expected an error for a missing column")
+	}
+
+	_, err = gc.EnrichCSV(strings.NewReader("id,street\n"), &out, geocodio.EnrichOptions{})
+	if !errors.Is(err, geocodio.ErrEnrichAddressColumnMissing) {
+		t.Error("Expected error", geocodio.ErrEnrichAddressColumnMissing, "but saw", err)
+	}
+}
+
+func TestEnrichCSVUnsupportedFieldFailsFast(t *testing.T) {
+	server, gc := newEnrichServer(t)
+
+	var out bytes.Buffer
+	_, err := gc.EnrichCSV(strings.NewReader("address\n"+AddressTestOneFull+"\n"), &out, geocodio.EnrichOptions{
+		AddressColumn: "address",
+		Fields:        geocodio.NewFieldSet(geocodio.FieldStateLegislativeDistrictsNext),
+	})
+	if !errors.Is(err, geocodio.ErrUnsupportedVersion) {
+		t.Error("Expected error", geocodio.ErrUnsupportedVersion, "but saw", err)
+	}
+	if out.Len() != 0 || len(server.Requests()) != 0 {
+		t.Error("Expected no output or requests but saw", out.String())
+	}
+}
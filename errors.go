@@ -1,6 +1,13 @@
 package geocodio
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
 
 var (
 	// ErrReverseGecodeMissingLatLng error when a lat/lng is not provided
@@ -17,4 +24,95 @@ var (
 	ErrReverseBatchInvalidCoordsPairs = errors.New("Invalid list of coordinate pairs")
 	// ErrNoResultsFound
 	ErrNoResultsFound = errors.New("No results found")
+	// ErrUnknownField error when a requested field is not supported by the API
+	ErrUnknownField = errors.New("Unknown field")
+)
+
+var (
+	// ErrUnauthorized matches API errors for a missing, invalid or disabled API key (401/403)
+	ErrUnauthorized = errors.New("Unauthorized")
+	// ErrUnprocessable matches API errors for input the API could not process (422)
+	ErrUnprocessable = errors.New("Unprocessable request")
+	// ErrRateLimited matches API errors for throttled requests (429)
+	ErrRateLimited = errors.New("Rate limited")
+	// ErrServer matches API errors for failures on the Geocodio side (5xx)
+	ErrServer = errors.New("Server error")
 )
+
+// APIError is returned for any non-2xx response from the API
+//
+//	var apiErr *geocodio.APIError
+//	if errors.As(err, &apiErr) && apiErr.Retryable() { ... }
+//
+//	if errors.Is(err, geocodio.ErrRateLimited) { ... }
+type APIError struct {
+	StatusCode int
+	Status     string
+	// Message is the "error" value of the response body, if any
+	Message string
+	// URL is the requested URL with the api_key parameter redacted
+	URL string
+	// RetryAfter is the wait requested by the API's Retry-After header, if any
+	RetryAfter time.Duration
+}
+
+func newAPIError(requestedURL, status string, statusCode int, header http.Header, body []byte) *APIError {
+	apiErr := APIError{
+		StatusCode: statusCode,
+		Status:     status,
+		URL:        redactURL(requestedURL),
+		RetryAfter: parseRetryAfter(header),
+	}
+
+	payload := struct {
+		Error string `json:"error"`
+	}{}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		apiErr.Message = payload.Error
+	}
+
+	return &apiErr
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	return fmt.Sprintf("geocodio: %d %s (%s)", e.StatusCode, msg, e.URL)
+}
+
+// Is reports whether the error belongs to one of the sentinel classes
+// ErrUnauthorized, ErrUnprocessable, ErrRateLimited or ErrServer
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrUnprocessable:
+		return e.StatusCode == http.StatusUnprocessableEntity
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// Retryable reports whether repeating the same request may succeed
+func (e *APIError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// redactURL hides the api_key query parameter so URLs are safe to log
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	q := u.Query()
+	if q.Get("api_key") != "" {
+		q.Set("api_key", "REDACTED")
+		u.RawQuery = q.Encode()
+	}
+	return u.String()
+}
@@ -0,0 +1,88 @@
+package geocodio_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/strategycomplex/go-geocodio"
+)
+
+func TestAPIErrorStatusClasses(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		sentinel   error
+		retryable  bool
+	}{
+		{http.StatusForbidden, geocodio.ErrUnauthorized, false},
+		{http.StatusUnprocessableEntity, geocodio.ErrUnprocessable, false},
+		{http.StatusTooManyRequests, geocodio.ErrRateLimited, true},
+		{http.StatusInternalServerError, geocodio.ErrServer, true},
+	}
+
+	for _, test := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(test.statusCode)
+			w.Write([]byte(`{"error": "Something went wrong"}`))
+		}))
+
+		gc, err := geocodio.NewWithOptions(
+			geocodio.WithAPIKey("secret-key"),
+			geocodio.WithBaseURL(server.URL),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = gc.Geocode(AddressTestOneFull)
+		server.Close()
+
+		if !errors.Is(err, test.sentinel) {
+			t.Errorf("%d: expected error %v but saw %v", test.statusCode, test.sentinel, err)
+		}
+
+		var apiErr *geocodio.APIError
+		if !errors.As(err, &apiErr) {
+			t.Errorf("%d: expected *APIError but saw %T", test.statusCode, err)
+			continue
+		}
+
+		if apiErr.StatusCode != test.statusCode {
+			t.Errorf("Expected status code %d but saw %d", test.statusCode, apiErr.StatusCode)
+		}
+		if apiErr.Message != "Something went wrong" {
+			t.Error("Unexpected message", apiErr.Message)
+		}
+		if apiErr.Retryable() != test.retryable {
+			t.Errorf("%d: expected retryable %t", test.statusCode, test.retryable)
+		}
+		if strings.Contains(apiErr.URL, "secret-key") || strings.Contains(apiErr.Error(), "secret-key") {
+			t.Error("API key was not redacted", apiErr.URL)
+		}
+	}
+}
+
+func TestAPIErrorBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"error": "Could not parse payload"}`))
+	}))
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = gc.GeocodeBatch(AddressTestOneFull)
+	if !errors.Is(err, geocodio.ErrUnprocessable) {
+		t.Error("Expected error", geocodio.ErrUnprocessable, "but saw", err)
+	}
+
+	_, err = gc.ReverseBatch(AddressTestOneLatitude, AddressTestOneLongitude)
+	if !errors.Is(err, geocodio.ErrUnprocessable) {
+		t.Error("Expected error", geocodio.ErrUnprocessable, "but saw", err)
+	}
+}
@@ -1,5 +1,12 @@
 package geocodio
 
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
 // Fields
 type Fields struct {
 	Timezone                  Timezone                  `json:"timezone,omitempty"`
@@ -10,4 +17,183 @@ type Fields struct {
 	SchoolDistricts           SchoolDistricts           `json:"school_districts,omitempty"`
 	Census                    CensusResults             `json:"census,omitempty"`
 	ACS                       CensusACS                 `json:"acs,omitempty"`
+	Riding                    Riding                    `json:"riding,omitempty"`
+	ProvincialRiding          ProvincialRiding          `json:"provriding,omitempty"`
+	StatCan                   StatCan                   `json:"statcan,omitempty"`
+}
+
+// UnmarshalJSON fills both CongressionalDistrict and CongressionalDistricts
+// whichever shape the API version returns
+func (f *Fields) UnmarshalJSON(data []byte) error {
+	type fields Fields
+	if err := json.Unmarshal(data, (*fields)(f)); err != nil {
+		return err
+	}
+
+	if len(f.CongressionalDistricts) == 0 && f.CongressionalDistrict.Name != "" {
+		f.CongressionalDistricts = []CongressionalDistrict{f.CongressionalDistrict}
+	}
+	if f.CongressionalDistrict.Name == "" && len(f.CongressionalDistricts) > 0 {
+		f.CongressionalDistrict = f.CongressionalDistricts[0]
+	}
+	return nil
+}
+
+// Field is a data append that can be requested with a lookup
+// See: https://www.geocod.io/docs/#fields
+type Field string
+
+const (
+	FieldTimezone                      Field = "timezone"
+	FieldZip4                          Field = "zip4"
+	FieldCongressionalDistrict         Field = "cd"
+	FieldStateLegislativeDistricts     Field = "stateleg"
+	FieldStateLegislativeDistrictsNext Field = "stateleg-next"
+	FieldSchoolDistricts               Field = "school"
+	FieldCensus                        Field = "census"
+	FieldACSDemographics               Field = "acs-demographics"
+	FieldACSEconomics                  Field = "acs-economics"
+	FieldACSFamilies                   Field = "acs-families"
+	FieldACSHousing                    Field = "acs-housing"
+	FieldACSSocial                     Field = "acs-social"
+	FieldRiding                        Field = "riding"
+	FieldProvincialRiding              Field = "provriding"
+	FieldStatCan                       Field = "statcan"
+)
+
+// allFields are the fields without a version suffix, in the order the API documents them
+var allFields = FieldSet{
+	FieldTimezone,
+	FieldZip4,
+	FieldCongressionalDistrict,
+	FieldStateLegislativeDistricts,
+	FieldStateLegislativeDistrictsNext,
+	FieldSchoolDistricts,
+	FieldCensus,
+	FieldACSDemographics,
+	FieldACSEconomics,
+	FieldACSFamilies,
+	FieldACSHousing,
+	FieldACSSocial,
+	FieldRiding,
+	FieldProvincialRiding,
+	FieldStatCan,
+}
+
+var knownFields = func() map[Field]bool {
+	known := make(map[Field]bool, len(allFields))
+	for _, field := range allFields {
+		known[field] = true
+	}
+	return known
+}()
+
+// KnownFields returns every field the package knows about, versioned fields
+// such as cd118 or census2020 are built with CongressionalDistrictFor and CensusYear
+func KnownFields() FieldSet {
+	return append(FieldSet(nil), allFields...)
+}
+
+// versionedFields match fields with a numeric suffix, such as cd118 or census2020
+var versionedFields = regexp.MustCompile(`^(cd[0-9]{3}|census[0-9]{4})$`)
+
+// CongressionalDistrictFor requests the districts of a specific congress, such as cd118
+func CongressionalDistrictFor(congress int) Field {
+	return Field(fmt.Sprintf("%s%d", FieldCongressionalDistrict, congress))
+}
+
+// CensusYear requests census geographies of a specific year, such as census2020
+func CensusYear(year int) Field {
+	return Field(fmt.Sprintf("%s%d", FieldCensus, year))
+}
+
+// Validate returns ErrUnknownField for fields the API does not support
+func (f Field) Validate() error {
+	if knownFields[f] || versionedFields.MatchString(string(f)) {
+		return nil
+	}
+	return fmt.Errorf("%w: %q", ErrUnknownField, string(f))
+}
+
+// FieldSet is a set of fields requested with a lookup
+//
+//	fields := geocodio.NewFieldSet(geocodio.FieldTimezone, geocodio.FieldCongressionalDistrict)
+//	result, err := gc.GeocodeWithFields(address, fields.With(geocodio.CensusYear(2020)))
+type FieldSet []Field
+
+// NewFieldSet creates a set of fields, dropping duplicates
+func NewFieldSet(fields ...Field) FieldSet {
+	return FieldSet(nil).With(fields...)
+}
+
+// ParseFieldSet creates a set from field names, each of which may be a
+// comma separated list such as "cd,stateleg". The fields are validated.
+func ParseFieldSet(names ...string) (FieldSet, error) {
+	set := fieldSetFromNames(names)
+	return set, set.Validate()
+}
+
+func fieldSetFromNames(names []string) FieldSet {
+	set := FieldSet{}
+	for _, name := range names {
+		for _, field := range strings.Split(name, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			set = set.With(Field(field))
+		}
+	}
+	return set
+}
+
+// With returns a new set containing the set's fields and the given fields
+func (s FieldSet) With(fields ...Field) FieldSet {
+	set := make(FieldSet, 0, len(s)+len(fields))
+	for _, field := range append(append(FieldSet{}, s...), fields...) {
+		if !set.Contains(field) {
+			set = append(set, field)
+		}
+	}
+	return set
+}
+
+// Union returns a new set containing the fields of both sets
+func (s FieldSet) Union(other FieldSet) FieldSet {
+	return s.With(other...)
+}
+
+// Contains reports whether the field is in the set
+func (s FieldSet) Contains(field Field) bool {
+	for _, f := range s {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns an error wrapping ErrUnknownField for the first field the
+// API does not support
+func (s FieldSet) Validate() error {
+	for _, field := range s {
+		if err := field.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Strings returns the field names
+func (s FieldSet) Strings() []string {
+	names := make([]string, len(s))
+	for i := range s {
+		names[i] = string(s[i])
+	}
+	return names
+}
+
+// String returns the comma separated field names as sent to the API
+func (s FieldSet) String() string {
+	return strings.Join(s.Strings(), ",")
 }
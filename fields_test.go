@@ -0,0 +1,145 @@
+package geocodio_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/strategycomplex/go-geocodio"
+)
+
+func TestFieldValidate(t *testing.T) {
+	valid := []geocodio.Field{
+		geocodio.FieldTimezone,
+		geocodio.FieldStateLegislativeDistrictsNext,
+		geocodio.FieldACSHousing,
+		geocodio.FieldStatCan,
+		geocodio.CongressionalDistrictFor(118),
+		geocodio.CensusYear(2020),
+	}
+	for _, field := range valid {
+		if err := field.Validate(); err != nil {
+			t.Error("Expected", field, "to be valid but saw", err)
+		}
+	}
+
+	for _, field := range []geocodio.Field{"timezones", "cd1", "census20", "acs", ""} {
+		if err := field.Validate(); !errors.Is(err, geocodio.ErrUnknownField) {
+			t.Errorf("Expected %q to be unknown but saw %v", field, err)
+		}
+	}
+}
+
+func TestFieldSet(t *testing.T) {
+	set := geocodio.NewFieldSet(geocodio.FieldTimezone, geocodio.FieldCongressionalDistrict, geocodio.FieldTimezone)
+	if set.String() != "timezone,cd" {
+		t.Error("Unexpected field set", set.String())
+	}
+
+	union := set.Union(geocodio.NewFieldSet(geocodio.FieldCongressionalDistrict, geocodio.CensusYear(2010)))
+	if union.String() != "timezone,cd,census2010" {
+		t.Error("Unexpected union", union.String())
+	}
+	if set.String() != "timezone,cd" {
+		t.Error("Union modified the original set", set.String())
+	}
+	if !union.Contains(geocodio.CensusYear(2010)) {
+		t.Error("Expected union to contain census2010")
+	}
+
+	parsed, err := geocodio.ParseFieldSet("cd,stateleg", " school ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.String() != "cd,stateleg,school" {
+		t.Error("Unexpected parsed set", parsed.String())
+	}
+
+	_, err = geocodio.ParseFieldSet("cd,bogus")
+	if !errors.Is(err, geocodio.ErrUnknownField) {
+		t.Error("Expected error", geocodio.ErrUnknownField, "but saw", err)
+	}
+}
+
+func TestGeocodeWithFieldsRejectsUnknownFields(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(optionsTestResponse))
+	}))
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = gc.GeocodeWithFields(AddressTestOneFull, geocodio.FieldSet{"bogus"})
+	if !errors.Is(err, geocodio.ErrUnknownField) {
+		t.Error("Expected error", geocodio.ErrUnknownField, "but saw", err)
+	}
+
+	_, err = gc.ReverseBatchWithOptions(geocodio.BatchOptions{Fields: geocodio.FieldSet{"bogus"}}, AddressTestOneLatitude, AddressTestOneLongitude)
+	if !errors.Is(err, geocodio.ErrUnknownField) {
+		t.Error("Expected error", geocodio.ErrUnknownField, "but saw", err)
+	}
+
+	if calls != 0 {
+		t.Error("Expected no requests but saw", calls)
+	}
+
+	_, err = gc.ReverseWithFields(AddressTestOneLatitude, AddressTestOneLongitude, geocodio.NewFieldSet(geocodio.FieldTimezone))
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFieldsDecodeVersionShapes(t *testing.T) {
+	// v1.0 style single districts with numeric district numbers
+	older := `{
+		"congressional_district": {"name": "Congressional District 8", "district_number": 8},
+		"state_legislative_districts": {
+			"house": {"name": "State House District 2", "district_number": 2, "proportion": 1},
+			"senate": {"name": "State Senate District 40", "district_number": 40}
+		}
+	}`
+
+	// v1.5+ style lists with string district numbers
+	newer := `{
+		"congressional_districts": [{"name": "Congressional District 8", "district_number": 8}],
+		"state_legislative_districts": {
+			"house": [
+				{"name": "State House District 2", "district_number": "2", "proportion": 0.75},
+				{"name": "State House District 3", "district_number": "3", "proportion": 0.25}
+			],
+			"senate": [{"name": "State Senate District 40", "district_number": "40", "proportion": 1}]
+		}
+	}`
+
+	for _, payload := range []string{older, newer} {
+		fields := geocodio.Fields{}
+		if err := json.Unmarshal([]byte(payload), &fields); err != nil {
+			t.Fatal(err)
+		}
+
+		if fields.CongressionalDistrict.DistrictNumber != 8 || len(fields.CongressionalDistricts) != 1 || fields.CongressionalDistricts[0].DistrictNumber != 8 {
+			t.Error("Unexpected congressional districts", fields.CongressionalDistrict, fields.CongressionalDistricts)
+		}
+		if fields.StateLegislativeDistricts.House.DistrictNumber != "2" {
+			t.Error("State Legislative Districts house does not match", fields.StateLegislativeDistricts.House)
+		}
+		if fields.StateLegislativeDistricts.Senate.DistrictNumber != "40" {
+			t.Error("State Legislative Districts senate does not match", fields.StateLegislativeDistricts.Senate)
+		}
+	}
+
+	fields := geocodio.Fields{}
+	if err := json.Unmarshal([]byte(newer), &fields); err != nil {
+		t.Fatal(err)
+	}
+	if len(fields.StateLegislativeDistricts.HouseDistricts) != 2 || fields.StateLegislativeDistricts.HouseDistricts[1].Proportion != 0.25 {
+		t.Error("Unexpected house districts", fields.StateLegislativeDistricts.HouseDistricts)
+	}
+}
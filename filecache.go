@@ -0,0 +1,248 @@
+package geocodio
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// fileCacheExt is the extension of entries written by FileCache
+	fileCacheExt = ".cache"
+	// fileCacheTmpPrefix starts the name of an entry while it is written
+	fileCacheTmpPrefix = ".tmp-"
+	// fileCacheTmpAge is how old a temporary file must be before Compact
+	// treats it as abandoned, younger ones may still be being written
+	fileCacheTmpAge = time.Hour
+)
+
+// FileCache is a Cache that keeps one file per entry in a directory, so cached
+// lookups survive restarts. Each file holds a one line JSON header with the key
+// and expiry followed by the raw response payload. It is safe for concurrent use,
+// including by several processes sharing the directory.
+type FileCache struct {
+	// OnError, if set, is called with filesystem errors that were treated as
+	// cache misses. Set it before the cache is shared between goroutines.
+	OnError func(err error)
+
+	dir    string
+	ttl    time.Duration
+	hits   uint64
+	misses uint64
+}
+
+type fileCacheHeader struct {
+	Key     string    `json:"key"`
+	Expires time.Time `json:"expires,omitempty"`
+}
+
+// NewFileCache stores entries under dir, creating it if needed, each for ttl
+// (0 keeps entries until they are deleted)
+func NewFileCache(dir string, ttl time.Duration) (*FileCache, error) {
+	if dir == "" {
+		return nil, errors.New("Cache directory must not be empty")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileCache{dir: dir, ttl: ttl}, nil
+}
+
+// Get returns the cached value for key
+func (c *FileCache) Get(key string) ([]byte, bool) {
+	path := c.path(key)
+
+	header, value, err := readFileCacheEntry(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			c.report(err)
+		}
+		atomic.AddUint64(&c.misses, 1)
+		return nil, false
+	}
+
+	if header.Key != key {
+		atomic.AddUint64(&c.misses, 1)
+		return nil, false
+	}
+
+	if !header.Expires.IsZero() && !time.Now().Before(header.Expires) {
+		c.remove(path)
+		atomic.AddUint64(&c.misses, 1)
+		return nil, false
+	}
+
+	atomic.AddUint64(&c.hits, 1)
+	return value, true
+}
+
+// Set caches value for key, a ttl of 0 uses the cache's default
+func (c *FileCache) Set(key string, value []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = c.ttl
+	}
+
+	header := fileCacheHeader{Key: key}
+	if ttl > 0 {
+		header.Expires = time.Now().Add(ttl).UTC()
+	}
+
+	if err := c.write(c.path(key), header, value); err != nil {
+		c.report(err)
+	}
+}
+
+// Delete removes key from the cache
+func (c *FileCache) Delete(key string) {
+	c.remove(c.path(key))
+}
+
+// Stats returns the hit and miss counters and the number of entries on disk
+func (c *FileCache) Stats() CacheStats {
+	stats := CacheStats{
+		Hits:   atomic.LoadUint64(&c.hits),
+		Misses: atomic.LoadUint64(&c.misses),
+	}
+	c.walk(func(path string, info os.FileInfo) {
+		if strings.HasSuffix(path, fileCacheExt) {
+			stats.Len++
+		}
+	})
+	return stats
+}
+
+// Compact removes expired or unreadable entries and temporary files abandoned
+// for over an hour, returning how many files were removed. Expired entries are
+// otherwise only removed when read.
+func (c *FileCache) Compact() (int, error) {
+	var (
+		removed int
+		now     = time.Now()
+	)
+
+	err := c.walk(func(path string, info os.FileInfo) {
+		switch {
+		case strings.HasSuffix(path, fileCacheExt):
+			header, _, err := readFileCacheEntry(path)
+			if err == nil && (header.Expires.IsZero() || now.Before(header.Expires)) {
+				return
+			}
+		case strings.HasPrefix(info.Name(), fileCacheTmpPrefix):
+			// another process may still be writing it
+			if now.Sub(info.ModTime()) < fileCacheTmpAge {
+				return
+			}
+		default:
+			return
+		}
+		if err := os.Remove(path); err == nil {
+			removed++
+		}
+	})
+
+	return removed, err
+}
+
+// path shards entries into subdirectories by the hash of their key
+func (c *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	name := hex.EncodeToString(sum[:])
+	return filepath.Join(c.dir, name[:2], name+fileCacheExt)
+}
+
+func (c *FileCache) write(path string, header fileCacheHeader, value []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file and rename it so readers never see a partial entry
+	tmp, err := ioutil.TempFile(filepath.Dir(path), fileCacheTmpPrefix)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	w.Write(line)
+	w.WriteByte('\n')
+	w.Write(value)
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (c *FileCache) remove(path string) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		c.report(err)
+	}
+}
+
+// walk calls fn with every file in the cache's shard directories
+func (c *FileCache) walk(fn func(path string, info os.FileInfo)) error {
+	shards, err := ioutil.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+
+	for _, shard := range shards {
+		if !shard.IsDir() {
+			continue
+		}
+		files, err := ioutil.ReadDir(filepath.Join(c.dir, shard.Name()))
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			if !file.IsDir() {
+				fn(filepath.Join(c.dir, shard.Name(), file.Name()), file)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *FileCache) report(err error) {
+	if c.OnError != nil {
+		c.OnError(err)
+	}
+}
+
+func readFileCacheEntry(path string) (fileCacheHeader, []byte, error) {
+	header := fileCacheHeader{}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return header, nil, err
+	}
+
+	i := bytes.IndexByte(content, '\n')
+	if i < 0 {
+		return header, nil, errors.New("Cache entry is missing its header: " + path)
+	}
+	if err := json.Unmarshal(content[:i], &header); err != nil {
+		return header, nil, err
+	}
+
+	return header, content[i+1:], nil
+}
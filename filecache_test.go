@@ -0,0 +1,138 @@
+package geocodio_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/strategycomplex/go-geocodio"
+)
+
+func TestFileCache(t *testing.T) {
+	cache, err := geocodio.NewFileCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache.Set("a", []byte(`{"results": []}`), 0)
+	cache.Set("b", []byte("2"), time.Millisecond)
+
+	if value, ok := cache.Get("a"); !ok || string(value) != `{"results": []}` {
+		t.Error("Expected a to be cached but saw", string(value))
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Expected b to have expired")
+	}
+
+	cache.Delete("a")
+	if _, ok := cache.Get("a"); ok {
+		t.Error("Expected a to be deleted")
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 1 || stats.Misses != 2 || stats.Len != 0 {
+		t.Error("Unexpected stats", stats)
+	}
+}
+
+func TestFileCacheCompact(t *testing.T) {
+	cache, err := geocodio.NewFileCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache.Set("a", []byte("1"), 0)
+	cache.Set("b", []byte("2"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	removed, err := cache.Compact()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 1 {
+		t.Error("Expected 1 entry to be removed but saw", removed)
+	}
+	if stats := cache.Stats(); stats.Len != 1 {
+		t.Error("Expected 1 cached entry but saw", stats.Len)
+	}
+}
+
+func TestFileCacheCompactTemporaryFiles(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := geocodio.NewFileCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shard := filepath.Join(dir, "ab")
+	if err := os.MkdirAll(shard, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{".tmp-writing", ".tmp-abandoned"} {
+		if err := os.WriteFile(filepath.Join(shard, name), []byte("partial"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(filepath.Join(shard, ".tmp-abandoned"), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if stats := cache.Stats(); stats.Len != 0 {
+		t.Error("Expected temporary files not to be counted but saw", stats.Len)
+	}
+
+	removed, err := cache.Compact()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 1 {
+		t.Error("Expected 1 file to be removed but saw", removed)
+	}
+	if _, err := os.Stat(filepath.Join(shard, ".tmp-writing")); err != nil {
+		t.Error("Expected the file being written to be kept", err)
+	}
+}
+
+func TestGeocodeFileCacheSurvivesRestart(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(optionsTestResponse))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	geocode := func() geocodio.GeocodeResult {
+		cache, err := geocodio.NewFileCache(dir, time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gc, err := geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithBaseURL(server.URL), geocodio.WithCache(cache))
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := gc.Geocode(AddressTestOneFull)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	live := geocode()
+	cached := geocode()
+
+	if requests != 1 {
+		t.Error("Expected 1 request but saw", requests)
+	}
+	if !reflect.DeepEqual(live.Results, cached.Results) || live.ResponseAsString() != cached.ResponseAsString() {
+		t.Error("Cached result does not match", live.Results, cached.Results)
+	}
+}
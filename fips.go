@@ -0,0 +1,317 @@
+package geocodio
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidGEOID is returned when a GEOID is not 2, 5, 11, 12 or 15 digits
+// or its state is unknown
+var ErrInvalidGEOID = errors.New("Invalid GEOID")
+
+// GEOIDLevel is the census geography a GEOID identifies
+type GEOIDLevel int
+
+// GEOID levels from the least to the most precise, with the number of
+// digits of their GEOID
+const (
+	GEOIDState      GEOIDLevel = iota + 1 // 2 digits, 51
+	GEOIDCounty                           // 5 digits, 51013
+	GEOIDTract                            // 11 digits, 51013101801
+	GEOIDBlockGroup                       // 12 digits, 510131018011
+	GEOIDBlock                            // 15 digits, 510131018011004
+)
+
+func (l GEOIDLevel) String() string {
+	switch l {
+	case GEOIDState:
+		return "state"
+	case GEOIDCounty:
+		return "county"
+	case GEOIDTract:
+		return "tract"
+	case GEOIDBlockGroup:
+		return "block group"
+	case GEOIDBlock:
+		return "block"
+	}
+	return fmt.Sprintf("GEOIDLevel(%d)", int(l))
+}
+
+// GEOID is a census geographic identifier split into the FIPS codes of each
+// level, such as the full_fips 510131018011004 of a Census result. Levels
+// below the GEOID's own are empty.
+type GEOID struct {
+	// State is the 2 digit state FIPS code, 51
+	State string
+	// County is the 3 digit county code within the state, 013
+	County string
+	// Tract is the 6 digit tract code within the county, 101801
+	Tract string
+	// BlockGroup is the 1 digit block group within the tract, 1
+	BlockGroup string
+	// Block is the 4 digit block code within the tract, starting with the
+	// block group, 1004
+	Block string
+}
+
+// ParseGEOID splits the GEOID of a state, county, tract, block group or
+// block into its FIPS codes
+func ParseGEOID(geoid string) (GEOID, error) {
+	for _, r := range geoid {
+		if r < '0' || r > '9' {
+			return GEOID{}, fmt.Errorf("%w: %q", ErrInvalidGEOID, geoid)
+		}
+	}
+
+	id := GEOID{}
+	switch len(geoid) {
+	case 15:
+		id.Block = geoid[11:15]
+		fallthrough
+	case 12:
+		id.BlockGroup = geoid[11:12]
+		fallthrough
+	case 11:
+		id.Tract = geoid[5:11]
+		fallthrough
+	case 5:
+		id.County = geoid[2:5]
+		fallthrough
+	case 2:
+		id.State = geoid[0:2]
+	default:
+		return GEOID{}, fmt.Errorf("%w: %q", ErrInvalidGEOID, geoid)
+	}
+
+	if _, ok := StateByFIPS(id.State); !ok {
+		return GEOID{}, fmt.Errorf("%w: unknown state %q", ErrInvalidGEOID, id.State)
+	}
+	return id, nil
+}
+
+// Level returns the most precise level of the GEOID, 0 if it is empty
+func (id GEOID) Level() GEOIDLevel {
+	switch {
+	case id.Block != "":
+		return GEOIDBlock
+	case id.BlockGroup != "":
+		return GEOIDBlockGroup
+	case id.Tract != "":
+		return GEOIDTract
+	case id.County != "":
+		return GEOIDCounty
+	case id.State != "":
+		return GEOIDState
+	}
+	return 0
+}
+
+// geoidCode is the code of one level and the number of digits it must have
+type geoidCode struct {
+	code   string
+	length int
+}
+
+// At returns the GEOID of the geography at level containing id, such as the
+// county GEOID 51013 of a block. It is empty when level is more precise than
+// id, or when a code of level or of a level above it is missing or malformed.
+func (id GEOID) At(level GEOIDLevel) string {
+	if level < GEOIDState || level > id.Level() {
+		return ""
+	}
+
+	codes := []geoidCode{{id.State, 2}, {id.County, 3}, {id.Tract, 6}}
+	switch level {
+	case GEOIDBlockGroup:
+		// a block code starts with its block group
+		blockGroup := id.BlockGroup
+		if id.Block != "" {
+			blockGroup = id.Block[:1]
+		}
+		codes = append(codes, geoidCode{blockGroup, 1})
+	case GEOIDBlock:
+		codes = append(codes, geoidCode{id.Block, 4})
+	default:
+		codes = codes[:level]
+	}
+
+	geoid := ""
+	for _, c := range codes {
+		if len(c.code) != c.length {
+			return ""
+		}
+		geoid += c.code
+	}
+	return geoid
+}
+
+// String returns the GEOID at its own level
+func (id GEOID) String() string {
+	return id.At(id.Level())
+}
+
+// StateGEOID returns the 2 digit state GEOID
+func (id GEOID) StateGEOID() string {
+	return id.At(GEOIDState)
+}
+
+// CountyGEOID returns the 5 digit county GEOID
+func (id GEOID) CountyGEOID() string {
+	return id.At(GEOIDCounty)
+}
+
+// TractGEOID returns the 11 digit tract GEOID
+func (id GEOID) TractGEOID() string {
+	return id.At(GEOIDTract)
+}
+
+// BlockGroupGEOID returns the 12 digit block group GEOID
+func (id GEOID) BlockGroupGEOID() string {
+	return id.At(GEOIDBlockGroup)
+}
+
+// BlockGEOID returns the 15 digit block GEOID
+func (id GEOID) BlockGEOID() string {
+	return id.At(GEOIDBlock)
+}
+
+// USState returns the state of the GEOID from the bundled table
+func (id GEOID) USState() (State, bool) {
+	return StateByFIPS(id.State)
+}
+
+// MarshalText writes the GEOID as its digits, so it can be used in JSON
+func (id GEOID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText parses the GEOID with ParseGEOID, an empty value is left empty
+func (id *GEOID) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*id = GEOID{}
+		return nil
+	}
+	parsed, err := ParseGEOID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// GEOID parses FullFIPS, falling back to the separate codes when it is
+// missing. It is the GEOID of the census block.
+func (c Census) GEOID() (GEOID, error) {
+	if c.FullFIPS != "" {
+		return ParseGEOID(c.FullFIPS)
+	}
+	return ParseGEOID(c.CountyFIPS + c.TractCode + c.BlockCode)
+}
+
+// State is a state, district or territory with a FIPS code
+type State struct {
+	// FIPS is the 2 digit state FIPS code
+	FIPS string
+	// USPS is the 2 letter postal abbreviation
+	USPS string
+	Name string
+}
+
+// states are the states, the District of Columbia and the territories by FIPS code
+var states = []State{
+	{"01", "AL", "Alabama"},
+	{"02", "AK", "Alaska"},
+	{"04", "AZ", "Arizona"},
+	{"05", "AR", "Arkansas"},
+	{"06", "CA", "California"},
+	{"08", "CO", "Colorado"},
+	{"09", "CT", "Connecticut"},
+	{"10", "DE", "Delaware"},
+	{"11", "DC", "District of Columbia"},
+	{"12", "FL", "Florida"},
+	{"13", "GA", "Georgia"},
+	{"15", "HI", "Hawaii"},
+	{"16", "ID", "Idaho"},
+	{"17", "IL", "Illinois"},
+	{"18", "IN", "Indiana"},
+	{"19", "IA", "Iowa"},
+	{"20", "KS", "Kansas"},
+	{"21", "KY", "Kentucky"},
+	{"22", "LA", "Louisiana"},
+	{"23", "ME", "Maine"},
+	{"24", "MD", "Maryland"},
+	{"25", "MA", "Massachusetts"},
+	{"26", "MI", "Michigan"},
+	{"27", "MN", "Minnesota"},
+	{"28", "MS", "Mississippi"},
+	{"29", "MO", "Missouri"},
+	{"30", "MT", "Montana"},
+	{"31", "NE", "Nebraska"},
+	{"32", "NV", "Nevada"},
+	{"33", "NH", "New Hampshire"},
+	{"34", "NJ", "New Jersey"},
+	{"35", "NM", "New Mexico"},
+	{"36", "NY", "New York"},
+	{"37", "NC", "North Carolina"},
+	{"38", "ND", "North Dakota"},
+	{"39", "OH", "Ohio"},
+	{"40", "OK", "Oklahoma"},
+	{"41", "OR", "Oregon"},
+	{"42", "PA", "Pennsylvania"},
+	{"44", "RI", "Rhode Island"},
+	{"45", "SC", "South Carolina"},
+	{"46", "SD", "South Dakota"},
+	{"47", "TN", "Tennessee"},
+	{"48", "TX", "Texas"},
+	{"49", "UT", "Utah"},
+	{"50", "VT", "Vermont"},
+	{"51", "VA", "Virginia"},
+	{"53", "WA", "Washington"},
+	{"54", "WV", "West Virginia"},
+	{"55", "WI", "Wisconsin"},
+	{"56", "WY", "Wyoming"},
+	{"60", "AS", "American Samoa"},
+	{"66", "GU", "Guam"},
+	{"69", "MP", "Northern Mariana Islands"},
+	{"72", "PR", "Puerto Rico"},
+	{"74", "UM", "U.S. Minor Outlying Islands"},
+	{"78", "VI", "U.S. Virgin Islands"},
+}
+
+var statesByFIPS, statesByUSPS, statesByName = func() (map[string]State, map[string]State, map[string]State) {
+	byFIPS := map[string]State{}
+	byUSPS := map[string]State{}
+	byName := map[string]State{}
+	for _, state := range states {
+		byFIPS[state.FIPS] = state
+		byUSPS[state.USPS] = state
+		byName[strings.ToLower(state.Name)] = state
+	}
+	return byFIPS, byUSPS, byName
+}()
+
+// States returns the states, the District of Columbia and the territories in
+// order of FIPS code
+func States() []State {
+	return append([]State(nil), states...)
+}
+
+// StateByFIPS looks up a state by its 2 digit FIPS code
+func StateByFIPS(fips string) (State, bool) {
+	state, ok := statesByFIPS[fips]
+	return state, ok
+}
+
+// StateByUSPS looks up a state by its postal abbreviation in any case
+func StateByUSPS(abbreviation string) (State, bool) {
+	state, ok := statesByUSPS[strings.ToUpper(strings.TrimSpace(abbreviation))]
+	return state, ok
+}
+
+// StateByName looks up a state by its name in any case
+func StateByName(name string) (State, bool) {
+	state, ok := statesByName[strings.ToLower(strings.Join(strings.Fields(name), " "))]
+	return state, ok
+}
@@ -0,0 +1,156 @@
+package geocodio_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/strategycomplex/go-geocodio"
+)
+
+func TestParseGEOID(t *testing.T) {
+	id, err := geocodio.ParseGEOID("510131018011004")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := geocodio.GEOID{State: "51", County: "013", Tract: "101801", BlockGroup: "1", Block: "1004"}
+	if id != want {
+		t.Errorf("Expected %+v but saw %+v", want, id)
+	}
+	if id.Level() != geocodio.GEOIDBlock {
+		t.Errorf("Expected a block GEOID but saw %s", id.Level())
+	}
+
+	for level, want := range map[geocodio.GEOIDLevel]string{
+		geocodio.GEOIDState:      "51",
+		geocodio.GEOIDCounty:     "51013",
+		geocodio.GEOIDTract:      "51013101801",
+		geocodio.GEOIDBlockGroup: "510131018011",
+		geocodio.GEOIDBlock:      "510131018011004",
+	} {
+		if got := id.At(level); got != want {
+			t.Errorf("Expected %s GEOID %q but saw %q", level, want, got)
+		}
+	}
+	if id.CountyGEOID() != "51013" || id.TractGEOID() != "51013101801" || id.String() != "510131018011004" {
+		t.Errorf("Unexpected GEOIDs of %+v", id)
+	}
+
+	state, ok := id.USState()
+	if !ok || state.USPS != "VA" || state.Name != "Virginia" {
+		t.Errorf("Unexpected state %+v", state)
+	}
+}
+
+func TestParseGEOIDLevels(t *testing.T) {
+	for geoid, level := range map[string]geocodio.GEOIDLevel{
+		"06":           geocodio.GEOIDState,
+		"06037":        geocodio.GEOIDCounty,
+		"06037206300":  geocodio.GEOIDTract,
+		"060372063002": geocodio.GEOIDBlockGroup,
+	} {
+		id, err := geocodio.ParseGEOID(geoid)
+		if err != nil {
+			t.Errorf("%s: %v", geoid, err)
+			continue
+		}
+		if id.Level() != level || id.String() != geoid {
+			t.Errorf("%s: expected a %s GEOID but saw %s %q", geoid, level, id.Level(), id.String())
+		}
+		if id.At(level+1) != "" {
+			t.Errorf("%s: expected no %s GEOID", geoid, level+1)
+		}
+	}
+
+	for _, geoid := range []string{"", "5", "510", "5101310180", "51013101801100", "51013-101801", "99013"} {
+		if _, err := geocodio.ParseGEOID(geoid); !errors.Is(err, geocodio.ErrInvalidGEOID) {
+			t.Errorf("%q: expected ErrInvalidGEOID but saw %v", geoid, err)
+		}
+	}
+}
+
+func TestGEOIDJSON(t *testing.T) {
+	value := struct {
+		GEOID geocodio.GEOID `json:"geoid"`
+	}{}
+	if err := json.Unmarshal([]byte(`{"geoid": "51013101801"}`), &value); err != nil {
+		t.Fatal(err)
+	}
+	if value.GEOID.TractGEOID() != "51013101801" {
+		t.Errorf("Unexpected GEOID %+v", value.GEOID)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"geoid":"51013101801"}` {
+		t.Errorf("Unexpected JSON %s", data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"geoid": "5101x"}`), &value); !errors.Is(err, geocodio.ErrInvalidGEOID) {
+		t.Errorf("Expected ErrInvalidGEOID but saw %v", err)
+	}
+}
+
+func TestCensusGEOID(t *testing.T) {
+	census := geocodio.Census{StateFIPS: "51", CountyFIPS: "51013", TractCode: "101801", BlockCode: "1004", BlockGroup: "1"}
+
+	id, err := census.GEOID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.BlockGroupGEOID() != "510131018011" {
+		t.Errorf("Unexpected GEOID %+v", id)
+	}
+
+	census.FullFIPS = "510131018021007"
+	if id, err := census.GEOID(); err != nil || id.Tract != "101802" {
+		t.Errorf("Expected FullFIPS to be used but saw %+v, %v", id, err)
+	}
+}
+
+func TestStateLookups(t *testing.T) {
+	if len(geocodio.States()) != 57 {
+		t.Errorf("Expected 50 states, DC and 6 territories but saw %d", len(geocodio.States()))
+	}
+
+	for _, lookup := range []func() (geocodio.State, bool){
+		func() (geocodio.State, bool) { return geocodio.StateByFIPS("72") },
+		func() (geocodio.State, bool) { return geocodio.StateByUSPS(" pr") },
+		func() (geocodio.State, bool) { return geocodio.StateByName("puerto  RICO") },
+	} {
+		state, ok := lookup()
+		if !ok || state != (geocodio.State{FIPS: "72", USPS: "PR", Name: "Puerto Rico"}) {
+			t.Errorf("Unexpected state %+v", state)
+		}
+	}
+
+	if _, ok := geocodio.StateByFIPS("03"); ok {
+		t.Error("Expected no state with FIPS 03")
+	}
+	if _, ok := geocodio.StateByUSPS("ON"); ok {
+		t.Error("Expected no state ON")
+	}
+}
+
+func TestGEOIDMissingCodes(t *testing.T) {
+	for _, id := range []geocodio.GEOID{
+		{State: "51", Block: "1004"},
+		{State: "51", County: "13", Tract: "101801"},
+		{County: "013"},
+	} {
+		if id.String() != "" {
+			t.Errorf("Expected no GEOID for %+v but saw %q", id, id.String())
+		}
+		if _, err := json.Marshal(id); err != nil {
+			t.Error(err)
+		}
+	}
+
+	id := geocodio.GEOID{State: "51", County: "013", Block: "1004"}
+	if id.CountyGEOID() != "51013" || id.TractGEOID() != "" {
+		t.Errorf("Expected only the levels with all their codes from %+v", id)
+	}
+}
@@ -1,7 +1,10 @@
 package geocodio
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 )
 
@@ -20,6 +23,31 @@ type BatchResponse struct {
 type BatchResult struct {
 	Query    string          `json:"query"`
 	Response BatchResultItem `json:"response"`
+	// QueryComponents is set instead of Query for component batch lookups
+	QueryComponents *AddressInput `json:"-"`
+}
+
+// UnmarshalJSON accepts the query either as a string or, for component
+// batch lookups, as an object
+func (self *BatchResult) UnmarshalJSON(data []byte) error {
+	raw := struct {
+		Query    json.RawMessage `json:"query"`
+		Response BatchResultItem `json:"response"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*self = BatchResult{Response: raw.Response}
+
+	if len(raw.Query) > 0 && raw.Query[0] == '{' {
+		self.QueryComponents = &AddressInput{}
+		return json.Unmarshal(raw.Query, self.QueryComponents)
+	}
+	if len(raw.Query) > 0 && string(raw.Query) != "null" {
+		return json.Unmarshal(raw.Query, &self.Query)
+	}
+	return nil
 }
 
 type BatchResultItem struct {
@@ -28,6 +56,32 @@ type BatchResultItem struct {
 	Error   string    `json:"error,omitempty"`
 }
 
+// batchMapResponse is the payload of a batch lookup keyed by caller IDs
+type batchMapResponse struct {
+	Results map[string]BatchResult `json:"results"`
+	Debug   struct {
+		RequestedURL string
+		Status       string
+		StatusCode   int
+		RawResponse  []byte
+	} `json:"-"`
+}
+
+func (self *batchMapResponse) SaveDebug(requestedURL, status string, statusCode int, body []byte) {
+	self.Debug.RequestedURL = requestedURL
+	self.Debug.Status = status
+	self.Debug.StatusCode = statusCode
+	self.Debug.RawResponse = body
+}
+
+func (self *batchMapResponse) items() map[string]BatchResultItem {
+	items := make(map[string]BatchResultItem, len(self.Results))
+	for key, result := range self.Results {
+		items[key] = result.Response
+	}
+	return items
+}
+
 // GeocodeResponse
 type GeocodeResult struct {
 	Input   Input    `json:"input,omitempty"`
@@ -86,12 +140,17 @@ func (self *BatchResponse) ResponseAsString() string {
 // Geocode single address
 // See: http://geocod.io/docs/#toc_4
 func (g *Geocodio) Geocode(address string) (GeocodeResult, error) {
+	return g.GeocodeContext(context.Background(), address)
+}
+
+// GeocodeContext is like Geocode but aborts the request when ctx is cancelled
+func (g *Geocodio) GeocodeContext(ctx context.Context, address string) (GeocodeResult, error) {
 	resp := GeocodeResult{}
 	if address == "" {
 		return resp, ErrAddressIsEmpty
 	}
 
-	err := g.get("/geocode", map[string]string{"q": address}, &resp)
+	err := g.lookup(ctx, "/geocode", map[string]string{"q": address}, &resp)
 	if err != nil {
 		return GeocodeResult{}, err
 	}
@@ -105,13 +164,120 @@ func (g *Geocodio) Geocode(address string) (GeocodeResult, error) {
 
 // GeocodeBatch look up addresses
 func (g *Geocodio) GeocodeBatch(addresses ...string) (BatchResponse, error) {
+	return g.GeocodeBatchContext(context.Background(), addresses...)
+}
+
+// GeocodeBatchContext is like GeocodeBatch but aborts the request when ctx is cancelled
+func (g *Geocodio) GeocodeBatchContext(ctx context.Context, addresses ...string) (BatchResponse, error) {
+	return g.GeocodeBatchWithOptionsContext(ctx, BatchOptions{}, addresses...)
+}
+
+// GeocodeBatchWithOptions looks up addresses, requesting fields and limiting
+// the results of every query
+/*
+ 	See: http://geocod.io/docs/#toc_22
+	Note:
+		Each field counts as an additional lookup per address
+*/
+func (g *Geocodio) GeocodeBatchWithOptions(opts BatchOptions, addresses ...string) (BatchResponse, error) {
+	return g.GeocodeBatchWithOptionsContext(context.Background(), opts, addresses...)
+}
+
+// GeocodeBatchWithOptionsContext is like GeocodeBatchWithOptions but aborts the request when ctx is cancelled
+func (g *Geocodio) GeocodeBatchWithOptionsContext(ctx context.Context, opts BatchOptions, addresses ...string) (BatchResponse, error) {
 	resp := BatchResponse{}
 	if len(addresses) == 0 {
 		return resp, ErrBatchAddressesIsEmpty
 	}
 
-	// TODO: support limit
-	err := g.post("/geocode", addresses, nil, &resp)
+	query, err := g.batchQuery(opts)
+	if err != nil {
+		return resp, err
+	}
+
+	err = g.batchLookup(ctx, "/geocode", addresses, query, &resp)
+	if err != nil {
+		return BatchResponse{}, err
+	}
+
+	if len(resp.Results) == 0 {
+		return resp, ErrNoResultsFound
+	}
+
+	return resp, nil
+}
+
+// GeocodeComponents geocodes an address given as separate components
+// instead of a single line
+func (g *Geocodio) GeocodeComponents(input AddressInput) (GeocodeResult, error) {
+	return g.GeocodeComponentsContext(context.Background(), input)
+}
+
+// GeocodeComponentsContext is like GeocodeComponents but aborts the request when ctx is cancelled
+func (g *Geocodio) GeocodeComponentsContext(ctx context.Context, input AddressInput) (GeocodeResult, error) {
+	resp := GeocodeResult{}
+	if input.IsEmpty() {
+		return resp, ErrAddressIsEmpty
+	}
+
+	input, err := input.normalize()
+	if err != nil {
+		return resp, err
+	}
+
+	err = g.lookup(ctx, "/geocode", input.query(), &resp)
+	if err != nil {
+		return GeocodeResult{}, err
+	}
+
+	if len(resp.Results) == 0 {
+		return resp, ErrNoResultsFound
+	}
+
+	return resp, nil
+}
+
+// GeocodeBatchComponents looks up addresses given as separate components
+func (g *Geocodio) GeocodeBatchComponents(inputs ...AddressInput) (BatchResponse, error) {
+	return g.GeocodeBatchComponentsContext(context.Background(), inputs...)
+}
+
+// GeocodeBatchComponentsContext is like GeocodeBatchComponents but aborts the request when ctx is cancelled
+func (g *Geocodio) GeocodeBatchComponentsContext(ctx context.Context, inputs ...AddressInput) (BatchResponse, error) {
+	return g.GeocodeBatchComponentsWithOptionsContext(ctx, BatchOptions{}, inputs...)
+}
+
+// GeocodeBatchComponentsWithOptions looks up addresses given as separate
+// components, requesting fields and limiting the results of every query
+func (g *Geocodio) GeocodeBatchComponentsWithOptions(opts BatchOptions, inputs ...AddressInput) (BatchResponse, error) {
+	return g.GeocodeBatchComponentsWithOptionsContext(context.Background(), opts, inputs...)
+}
+
+// GeocodeBatchComponentsWithOptionsContext is like GeocodeBatchComponentsWithOptions but aborts the request when ctx is cancelled
+func (g *Geocodio) GeocodeBatchComponentsWithOptionsContext(ctx context.Context, opts BatchOptions, inputs ...AddressInput) (BatchResponse, error) {
+	resp := BatchResponse{}
+	if len(inputs) == 0 {
+		return resp, ErrBatchAddressesIsEmpty
+	}
+
+	normalized := make([]AddressInput, len(inputs))
+	for i := range inputs {
+		if inputs[i].IsEmpty() {
+			return resp, ErrAddressIsEmpty
+		}
+		input, err := inputs[i].normalize()
+		if err != nil {
+			return resp, fmt.Errorf("address %d: %w", i, err)
+		}
+		normalized[i] = input
+	}
+
+	query, err := g.batchQuery(opts)
+	if err != nil {
+		return resp, err
+	}
+
+	err = g.post(ctx, "/geocode", normalized, query, &resp)
 	if err != nil {
 		return BatchResponse{}, err
 	}
@@ -123,33 +289,184 @@ func (g *Geocodio) GeocodeBatch(addresses ...string) (BatchResponse, error) {
 	return resp, nil
 }
 
+// GeocodeBatchMap looks up addresses keyed by caller IDs, such as database
+// primary keys, and returns each result under the same key
+func (g *Geocodio) GeocodeBatchMap(addresses map[string]string) (map[string]BatchResultItem, error) {
+	return g.GeocodeBatchMapContext(context.Background(), addresses)
+}
+
+// GeocodeBatchMapContext is like GeocodeBatchMap but aborts the request when ctx is cancelled
+func (g *Geocodio) GeocodeBatchMapContext(ctx context.Context, addresses map[string]string) (map[string]BatchResultItem, error) {
+	return g.geocodeBatchMap(ctx, BatchOptions{}, addresses)
+}
+
+func (g *Geocodio) geocodeBatchMap(ctx context.Context, opts BatchOptions, addresses map[string]string) (map[string]BatchResultItem, error) {
+	if len(addresses) == 0 {
+		return nil, ErrBatchAddressesIsEmpty
+	}
+
+	for _, address := range addresses {
+		if address == "" {
+			return nil, ErrAddressIsEmpty
+		}
+	}
+
+	query, err := g.batchQuery(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := batchMapResponse{}
+	err = g.post(ctx, "/geocode", addresses, query, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Results) == 0 {
+		return nil, ErrNoResultsFound
+	}
+
+	return resp.items(), nil
+}
+
 // GeocodeAndReturnTimezone will geocode and include Timezone in the fields response
 func (g *Geocodio) GeocodeAndReturnTimezone(address string) (GeocodeResult, error) {
-	return g.GeocodeReturnFields(address, "timezone")
+	return g.GeocodeWithFields(address, NewFieldSet(FieldTimezone))
+}
+
+// GeocodeAndReturnTimezoneContext is like GeocodeAndReturnTimezone but honors ctx
+func (g *Geocodio) GeocodeAndReturnTimezoneContext(ctx context.Context, address string) (GeocodeResult, error) {
+	return g.GeocodeWithFieldsContext(ctx, address, NewFieldSet(FieldTimezone))
 }
 
 // GeocodeAndReturnZip4 will geocode and include zip4 in the fields response
 func (g *Geocodio) GeocodeAndReturnZip4(address string) (GeocodeResult, error) {
-	return g.GeocodeReturnFields(address, "zip4")
+	return g.GeocodeWithFields(address, NewFieldSet(FieldZip4))
+}
+
+// GeocodeAndReturnZip4Context is like GeocodeAndReturnZip4 but honors ctx
+func (g *Geocodio) GeocodeAndReturnZip4Context(ctx context.Context, address string) (GeocodeResult, error) {
+	return g.GeocodeWithFieldsContext(ctx, address, NewFieldSet(FieldZip4))
 }
 
 // GeocodeAndReturnCongressionalDistrict will geocode and include Congressional District in the fields response
 func (g *Geocodio) GeocodeAndReturnCongressionalDistrict(address string) (GeocodeResult, error) {
-	return g.GeocodeReturnFields(address, "cd")
+	return g.GeocodeWithFields(address, NewFieldSet(FieldCongressionalDistrict))
+}
+
+// GeocodeAndReturnCongressionalDistrictContext is like GeocodeAndReturnCongressionalDistrict but honors ctx
+func (g *Geocodio) GeocodeAndReturnCongressionalDistrictContext(ctx context.Context, address string) (GeocodeResult, error) {
+	return g.GeocodeWithFieldsContext(ctx, address, NewFieldSet(FieldCongressionalDistrict))
 }
 
 // GeocodeAndReturnStateLegislativeDistricts will geocode and include State Legislative Districts in the fields response
 func (g *Geocodio) GeocodeAndReturnStateLegislativeDistricts(address string) (GeocodeResult, error) {
-	return g.GeocodeReturnFields(address, "stateleg")
+	return g.GeocodeWithFields(address, NewFieldSet(FieldStateLegislativeDistricts))
+}
+
+// GeocodeAndReturnStateLegislativeDistrictsContext is like GeocodeAndReturnStateLegislativeDistricts but honors ctx
+func (g *Geocodio) GeocodeAndReturnStateLegislativeDistrictsContext(ctx context.Context, address string) (GeocodeResult, error) {
+	return g.GeocodeWithFieldsContext(ctx, address, NewFieldSet(FieldStateLegislativeDistricts))
 }
 
 // GeocodeAndReturnCongressAndStateDistricts will geocode and include Congressional District and State Legislative Districts in the fields response
 func (g *Geocodio) GeocodeAndReturnCongressAndStateDistricts(address string) (GeocodeResult, error) {
-	return g.GeocodeReturnFields(address, "cd,stateleg")
+	return g.GeocodeWithFields(address, NewFieldSet(FieldCongressionalDistrict, FieldStateLegislativeDistricts))
+}
+
+// GeocodeAndReturnCongressAndStateDistrictsContext is like GeocodeAndReturnCongressAndStateDistricts but honors ctx
+func (g *Geocodio) GeocodeAndReturnCongressAndStateDistrictsContext(ctx context.Context, address string) (GeocodeResult, error) {
+	return g.GeocodeWithFieldsContext(ctx, address, NewFieldSet(FieldCongressionalDistrict, FieldStateLegislativeDistricts))
 }
 
 // TODO: School District (school)
 
+// GeocodeAndReturnRiding will geocode and include the Canadian federal Riding in the fields response
+func (g *Geocodio) GeocodeAndReturnRiding(address string) (GeocodeResult, error) {
+	return g.GeocodeWithFields(address, NewFieldSet(FieldRiding))
+}
+
+// GeocodeAndReturnRidingContext is like GeocodeAndReturnRiding but honors ctx
+func (g *Geocodio) GeocodeAndReturnRidingContext(ctx context.Context, address string) (GeocodeResult, error) {
+	return g.GeocodeWithFieldsContext(ctx, address, NewFieldSet(FieldRiding))
+}
+
+// GeocodeAndReturnProvincialRiding will geocode and include the Canadian Provincial Riding in the fields response
+func (g *Geocodio) GeocodeAndReturnProvincialRiding(address string) (GeocodeResult, error) {
+	return g.GeocodeWithFields(address, NewFieldSet(FieldProvincialRiding))
+}
+
+// GeocodeAndReturnProvincialRidingContext is like GeocodeAndReturnProvincialRiding but honors ctx
+func (g *Geocodio) GeocodeAndReturnProvincialRidingContext(ctx context.Context, address string) (GeocodeResult, error) {
+	return g.GeocodeWithFieldsContext(ctx, address, NewFieldSet(FieldProvincialRiding))
+}
+
+// GeocodeAndReturnFederalAndProvincialRidings will geocode and include the Riding and Provincial Riding in the fields response
+func (g *Geocodio) GeocodeAndReturnFederalAndProvincialRidings(address string) (GeocodeResult, error) {
+	return g.GeocodeWithFields(address, NewFieldSet(FieldRiding, FieldProvincialRiding))
+}
+
+// GeocodeAndReturnFederalAndProvincialRidingsContext is like GeocodeAndReturnFederalAndProvincialRidings but honors ctx
+func (g *Geocodio) GeocodeAndReturnFederalAndProvincialRidingsContext(ctx context.Context, address string) (GeocodeResult, error) {
+	return g.GeocodeWithFieldsContext(ctx, address, NewFieldSet(FieldRiding, FieldProvincialRiding))
+}
+
+// GeocodeAndReturnStatCan will geocode and include Statistics Canada geographies in the fields response
+func (g *Geocodio) GeocodeAndReturnStatCan(address string) (GeocodeResult, error) {
+	return g.GeocodeWithFields(address, NewFieldSet(FieldStatCan))
+}
+
+// GeocodeAndReturnStatCanContext is like GeocodeAndReturnStatCan but honors ctx
+func (g *Geocodio) GeocodeAndReturnStatCanContext(ctx context.Context, address string) (GeocodeResult, error) {
+	return g.GeocodeWithFieldsContext(ctx, address, NewFieldSet(FieldStatCan))
+}
+
+// GeocodeWithFields will geocode and include the given fields in the response.
+// Unknown fields are rejected before any request is made.
+func (g *Geocodio) GeocodeWithFields(address string, fields FieldSet) (GeocodeResult, error) {
+	return g.GeocodeWithFieldsContext(context.Background(), address, fields)
+}
+
+// GeocodeWithFieldsContext is like GeocodeWithFields but aborts the request when ctx is cancelled
+func (g *Geocodio) GeocodeWithFieldsContext(ctx context.Context, address string, fields FieldSet) (GeocodeResult, error) {
+	if err := fields.Validate(); err != nil {
+		return GeocodeResult{}, err
+	}
+	return g.GeocodeReturnFieldsContext(ctx, address, fields.Strings()...)
+}
+
+// GeocodeWithOptions geocodes a single address with the fields, limit and
+// country of opts. Setting Country keeps an address that exists in both the
+// US and Canada from being matched in the wrong one.
+func (g *Geocodio) GeocodeWithOptions(address string, opts BatchOptions) (GeocodeResult, error) {
+	return g.GeocodeWithOptionsContext(context.Background(), address, opts)
+}
+
+// GeocodeWithOptionsContext is like GeocodeWithOptions but aborts the request when ctx is cancelled
+func (g *Geocodio) GeocodeWithOptionsContext(ctx context.Context, address string, opts BatchOptions) (GeocodeResult, error) {
+	resp := GeocodeResult{}
+	if address == "" {
+		return resp, ErrAddressIsEmpty
+	}
+
+	query, err := g.batchQuery(opts)
+	if err != nil {
+		return resp, err
+	}
+	query["q"] = address
+
+	err = g.lookup(ctx, "/geocode", query, &resp)
+	if err != nil {
+		return GeocodeResult{}, err
+	}
+
+	if len(resp.Results) == 0 {
+		return resp, ErrNoResultsFound
+	}
+
+	return resp, nil
+}
+
 // GeocodeReturnFields will geocode and includes additional fields in response
 /*
  	See: http://geocod.io/docs/#toc_22
@@ -157,14 +474,23 @@ func (g *Geocodio) GeocodeAndReturnCongressAndStateDistricts(address string) (Ge
 		Each field counts as an additional lookup each
 */
 func (g *Geocodio) GeocodeReturnFields(address string, fields ...string) (GeocodeResult, error) {
+	return g.GeocodeReturnFieldsContext(context.Background(), address, fields...)
+}
+
+// GeocodeReturnFieldsContext is like GeocodeReturnFields but aborts the request when ctx is cancelled
+func (g *Geocodio) GeocodeReturnFieldsContext(ctx context.Context, address string, fields ...string) (GeocodeResult, error) {
 	resp := GeocodeResult{}
 	if address == "" {
 		return resp, errors.New("address can not be empty")
 	}
 
+	if err := g.checkFieldVersions(fieldSetFromNames(fields)); err != nil {
+		return resp, err
+	}
+
 	fieldsCommaSeparated := strings.Join(fields, ",")
 
-	err := g.get("/geocode",
+	err := g.lookup(ctx, "/geocode",
 		map[string]string{
 			"q":      address,
 			"fields": fieldsCommaSeparated,
@@ -1,6 +1,8 @@
 package geocodio_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -8,6 +10,7 @@ import (
 )
 
 func TestGeocodeWithEmptyAddress(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -19,6 +22,7 @@ func TestGeocodeWithEmptyAddress(t *testing.T) {
 }
 
 func TestGeocodeDebugResponseAsString(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -35,6 +39,7 @@ func TestGeocodeDebugResponseAsString(t *testing.T) {
 }
 
 func TestGeocodeFullAddress(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -60,6 +65,7 @@ func TestGeocodeFullAddress(t *testing.T) {
 }
 
 func TestGeocodeFullAddressReturningTimezone(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -91,6 +97,7 @@ func TestGeocodeFullAddressReturningTimezone(t *testing.T) {
 }
 
 func TestGeocodeFullAddressReturningZip4(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -123,6 +130,7 @@ func TestGeocodeFullAddressReturningZip4(t *testing.T) {
 }
 
 func TestGeocodeFullAddressReturningCongressionalDistrict(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -165,6 +173,7 @@ func TestGeocodeFullAddressReturningCongressionalDistrict(t *testing.T) {
 }
 
 func TestGeocodeFullAddressReturningStateLegislativeDistricts(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -205,6 +214,7 @@ func TestGeocodeFullAddressReturningStateLegislativeDistricts(t *testing.T) {
 }
 
 func TestGeocodeFullAddressReturningMultipleFields(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -256,6 +266,7 @@ func TestGeocodeFullAddressReturningMultipleFields(t *testing.T) {
 }
 
 func TestGeocodeBatchGeocode(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -280,6 +291,7 @@ func TestGeocodeBatchGeocode(t *testing.T) {
 }
 
 func TestGeocodeBatchEmptyListGeocode(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -293,6 +305,7 @@ func TestGeocodeBatchEmptyListGeocode(t *testing.T) {
 }
 
 func TestGeocodeInvalidNoResults(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -310,6 +323,7 @@ func TestGeocodeInvalidNoResults(t *testing.T) {
 }
 
 func TestGeocodeBatchInvalidNoResults(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -326,3 +340,23 @@ func TestGeocodeBatchInvalidNoResults(t *testing.T) {
 }
 
 // TODO: School District (school)
+
+func TestGeocodeContextCancelled(t *testing.T) {
+	gc, err := geocodio.New("test-key")
+	if err != nil {
+		t.Error("Failed with API KEY set.", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = gc.GeocodeContext(ctx, AddressTestOneFull)
+	if !errors.Is(err, context.Canceled) {
+		t.Error("Expected error", context.Canceled, "but saw", err)
+	}
+
+	_, err = gc.GeocodeBatchContext(ctx, AddressTestOneFull, AddressTestTwoFull)
+	if !errors.Is(err, context.Canceled) {
+		t.Error("Expected error", context.Canceled, "but saw", err)
+	}
+}
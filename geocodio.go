@@ -2,18 +2,31 @@ package geocodio
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
+	"time"
 )
 
 const (
-	// GeocodioAPIBaseURLv1 is the Geocod.io Base URL
-	GeocodioAPIBaseURLv1 = "https://api.geocod.io/v1.6"
+	// GeocodioAPIBaseURLv1 is the Geocod.io Base URL for the default API version
+	GeocodioAPIBaseURLv1 = GeocodioAPIHost + "/" + string(DefaultAPIVersion)
 )
 
 // Geocodio is the base struct
 type Geocodio struct {
 	APIKey string
+
+	httpClient *http.Client
+	baseURL    string
+	timeout    time.Duration
+	timeoutSet bool
+	userAgent  string
+	version    APIVersion
+
+	retryPolicy RetryPolicy
+	rateLimiter *RateLimiter
+	cache       Cache
 }
 
 type Input struct {
@@ -25,10 +38,7 @@ type Input struct {
 // or passed in as the first string value
 func New(apiKey ...string) (*Geocodio, error) {
 
-	key := os.Getenv(EnvGeocodioAPIKey)
-	if strings.TrimSpace(key) == "" {
-		key = os.Getenv(EnvOldAPIKey)
-	}
+	key := envAPIKey()
 
 	if len(apiKey) == 0 && strings.TrimSpace(key) == "" {
 		return nil, ErrMissingAPIKey
@@ -49,6 +59,40 @@ func New(apiKey ...string) (*Geocodio, error) {
 	return &g, nil
 }
 
+// NewWithOptions creates a Geocodio instance configured by options, such as
+//
+//	geocodio.NewWithOptions(
+//		geocodio.WithHTTPClient(client),
+//		geocodio.WithTimeout(30*time.Second),
+//	)
+//
+// The API key is read from the environment unless WithAPIKey is provided
+func NewWithOptions(opts ...Option) (*Geocodio, error) {
+	g := Geocodio{
+		APIKey: envAPIKey(),
+	}
+
+	for _, opt := range opts {
+		if err := opt(&g); err != nil {
+			return nil, err
+		}
+	}
+
+	if strings.TrimSpace(g.APIKey) == "" {
+		return nil, ErrMissingAPIKey
+	}
+
+	return &g, nil
+}
+
+func envAPIKey() string {
+	key := os.Getenv(EnvGeocodioAPIKey)
+	if strings.TrimSpace(key) == "" {
+		key = os.Getenv(EnvOldAPIKey)
+	}
+	return key
+}
+
 // NewGeocodio is a helper to create new Geocodio reference
 // since 1.6+ this is kept for backwards compatiblity
 // this is deprecatd and will be removed in 2+
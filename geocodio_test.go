@@ -45,7 +45,17 @@ const (
 	AddressTestThreeLongitude  = -117.158124
 )
 
+// skipWithoutAPIKey skips a test that calls the live API when no API key is
+// set, the other tests use geocodiotest instead
+func skipWithoutAPIKey(t *testing.T) {
+	t.Helper()
+	if os.Getenv(geocodio.EnvGeocodioAPIKey) == "" && os.Getenv(geocodio.EnvOldAPIKey) == "" {
+		t.Skip("Set " + geocodio.EnvGeocodioAPIKey + " to run tests against the live API")
+	}
+}
+
 func TestGeocodioWithApiKey(t *testing.T) {
+	skipWithoutAPIKey(t)
 
 	_, err := geocodio.New()
 	if err != nil {
@@ -90,6 +100,7 @@ func TestGeocodioWithoutApiKeyEnvAndEmptyString(t *testing.T) {
 }
 
 func TestGeocodioDeprecatedWithApiKey(t *testing.T) {
+	skipWithoutAPIKey(t)
 	_, err := geocodio.NewGeocodio(os.Getenv(geocodio.EnvGeocodioAPIKey))
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -104,6 +115,7 @@ func TestGeocodioDeprecatedWithoutApiKey(t *testing.T) {
 }
 
 func TestGeocodioWithOldApiKey(t *testing.T) {
+	skipWithoutAPIKey(t)
 	os.Setenv(geocodio.EnvOldAPIKey, os.Getenv(geocodio.EnvGeocodioAPIKey))
 	os.Setenv(geocodio.EnvGeocodioAPIKey, "")
 
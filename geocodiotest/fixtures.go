@@ -0,0 +1,84 @@
+package geocodiotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/strategycomplex/go-geocodio"
+)
+
+// Fixtures are API responses keyed by query, in the form
+//
+//	{
+//		"geocode": {"1109 N Highland St, Arlington VA": {"results": [...]}},
+//		"reverse": {"38.886672,-77.094735": {"results": [...]}}
+//	}
+//
+// Each response is a complete /geocode or /reverse payload, as returned by
+// the API with every field requested, so they can be captured from live calls.
+type Fixtures struct {
+	Geocode map[string]json.RawMessage `json:"geocode"`
+	Reverse map[string]json.RawMessage `json:"reverse"`
+}
+
+// LoadFixtures reads fixtures from r and serves them in addition to any
+// already loaded
+func (s *Server) LoadFixtures(r io.Reader) error {
+	fixtures := Fixtures{}
+	if err := json.NewDecoder(r).Decode(&fixtures); err != nil {
+		return err
+	}
+
+	reverses := make(map[string]json.RawMessage, len(fixtures.Reverse))
+	for query, payload := range fixtures.Reverse {
+		location, err := parseLocation(query)
+		if err != nil {
+			return err
+		}
+		reverses[location.String()] = payload
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for query, payload := range fixtures.Geocode {
+		s.geocodes[normalizeQuery(query)] = payload
+	}
+	for key, payload := range reverses {
+		s.reverses[key] = payload
+	}
+	return nil
+}
+
+// LoadFixtureFile reads fixtures from the file at path, see LoadFixtures
+func (s *Server) LoadFixtureFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := s.LoadFixtures(f); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+func parseLocation(query string) (geocodio.Location, error) {
+	parts := strings.Split(query, ",")
+	if len(parts) != 2 {
+		return geocodio.Location{}, fmt.Errorf("Reverse fixture %q is not a lat,lng pair", query)
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return geocodio.Location{}, err
+	}
+	lng, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return geocodio.Location{}, err
+	}
+	return geocodio.Location{Latitude: lat, Longitude: lng}, nil
+}
@@ -0,0 +1,238 @@
+package geocodiotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrUnmatchedRequest is returned in replay mode for requests that are not in the cassette
+var ErrUnmatchedRequest = errors.New("Request was not recorded in the cassette")
+
+// RecordEnv is the environment variable conventionally used to switch tests
+// from replaying cassettes to recording them, see ModeFromEnv
+const RecordEnv = "GEOCODIO_RECORD"
+
+// Mode selects whether a Recorder records or replays
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette and fails unmatched requests
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the API and records them to the cassette
+	ModeRecord
+)
+
+// ModeFromEnv is ModeRecord when RecordEnv is set, otherwise ModeReplay
+func ModeFromEnv() Mode {
+	if os.Getenv(RecordEnv) != "" {
+		return ModeRecord
+	}
+	return ModeReplay
+}
+
+// Cassette is a file of recorded request/response pairs
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response, the api_key query
+// parameter is never recorded
+type Interaction struct {
+	Request struct {
+		Method string `json:"method"`
+		URL    string `json:"url"`
+		Body   string `json:"body,omitempty"`
+	} `json:"request"`
+	Response struct {
+		StatusCode int         `json:"status_code"`
+		Header     http.Header `json:"header,omitempty"`
+		Body       string      `json:"body"`
+	} `json:"response"`
+}
+
+// Recorder is an http.RoundTripper that records API traffic to a cassette
+// file or replays it, for use with geocodio.WithHTTPClient:
+//
+//	rec, err := geocodiotest.NewRecorder("testdata/geocode.json", geocodiotest.ModeFromEnv())
+//	defer rec.Save()
+//	gc, err := geocodio.NewWithOptions(geocodio.WithHTTPClient(rec.Client()))
+//
+// Replayed requests are matched by method, URL without the api_key and body.
+// Identical requests are served in the order they were recorded.
+type Recorder struct {
+	// Transport sends requests while recording, http.DefaultTransport if nil
+	Transport http.RoundTripper
+
+	mode     Mode
+	path     string
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewRecorder records to or replays from the cassette at path. Replaying
+// requires the cassette to exist.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{mode: mode, path: path}
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &r.cassette); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// Client returns an HTTP client using the recorder as its transport
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Save writes the cassette when recording, it does nothing when replaying
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	content, err := json.MarshalIndent(r.cassette, "", "\t")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, append(content, '\n'), 0o644)
+}
+
+// Unused returns the recorded interactions that have not been replayed, which
+// usually means the code under test no longer makes a request it used to
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, used := range r.used {
+		if !used {
+			unused = append(unused, r.cassette.Interactions[i])
+		}
+	}
+	return unused
+}
+
+// RoundTrip records or replays req
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.mode == ModeRecord {
+		return r.record(req, body)
+	}
+	return r.replay(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	out := req.Clone(req.Context())
+	out.Body = ioutil.NopCloser(bytes.NewReader(body))
+	resp, err := transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := Interaction{}
+	interaction.Request.Method = req.Method
+	interaction.Request.URL = scrubURL(req.URL)
+	interaction.Request.Body = string(body)
+	interaction.Response.StatusCode = resp.StatusCode
+	interaction.Response.Header = resp.Header
+	interaction.Response.Body = string(respBody)
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.used = append(r.used, true)
+	r.mu.Unlock()
+
+	return interaction.response(req), nil
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	u := scrubURL(req.URL)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || interaction.Request.Method != req.Method ||
+			interaction.Request.URL != u || interaction.Request.Body != string(body) {
+			continue
+		}
+		r.used[i] = true
+		return interaction.response(req), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s (%s)", ErrUnmatchedRequest, req.Method, u, r.path)
+}
+
+func (i Interaction) response(req *http.Request) *http.Response {
+	header := http.Header{}
+	for k, v := range i.Response.Header {
+		header[k] = append([]string(nil), v...)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+		StatusCode:    i.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(i.Response.Body)),
+		ContentLength: int64(len(i.Response.Body)),
+		Request:       req,
+	}
+}
+
+// scrubURL drops the api_key and sorts the remaining query parameters so
+// recordings are stable and safe to commit
+func scrubURL(u *url.URL) string {
+	query := u.Query()
+	query.Del("api_key")
+
+	scrubbed := *u
+	scrubbed.RawQuery = query.Encode()
+	return scrubbed.String()
+}
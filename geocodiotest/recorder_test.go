@@ -0,0 +1,82 @@
+package geocodiotest_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/strategycomplex/go-geocodio"
+	"github.com/strategycomplex/go-geocodio/geocodiotest"
+)
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	server := newServer(t)
+	cassette := filepath.Join(t.TempDir(), "cassettes", "geocode.json")
+
+	rec, err := geocodiotest.NewRecorder(cassette, geocodiotest.ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gc, err := server.Geocodio(geocodio.WithHTTPClient(rec.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	live, err := gc.GeocodeAndReturnTimezone(highland)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gc.GeocodeBatch(highland); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	content, err := ioutil.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), geocodiotest.APIKey) {
+		t.Error("Cassette contains the API key")
+	}
+
+	rec, err = geocodiotest.NewRecorder(cassette, geocodiotest.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gc, err = geocodio.NewWithOptions(
+		geocodio.WithAPIKey("another-key"),
+		geocodio.WithBaseURL(server.URL),
+		geocodio.WithHTTPClient(rec.Client()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayed, err := gc.GeocodeAndReturnTimezone(highland)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed.ResponseAsString() != live.ResponseAsString() {
+		t.Error("Replayed response does not match", replayed.ResponseAsString())
+	}
+	if unused := rec.Unused(); len(unused) != 1 || unused[0].Request.Method != "POST" {
+		t.Error("Expected the batch request to be unused", unused)
+	}
+
+	// each recording is only replayed once
+	_, err = gc.GeocodeAndReturnTimezone(highland)
+	if !errors.Is(err, geocodiotest.ErrUnmatchedRequest) {
+		t.Error("Expected error", geocodiotest.ErrUnmatchedRequest, "but saw", err)
+	}
+}
+
+func TestRecorderMissingCassette(t *testing.T) {
+	_, err := geocodiotest.NewRecorder(filepath.Join(t.TempDir(), "missing.json"), geocodiotest.ModeReplay)
+	if err == nil {
+		t.Error("Expected an error replaying a missing cassette")
+	}
+}
@@ -0,0 +1,445 @@
+// Package geocodiotest provides an in-process fake Geocodio API for tests.
+//
+//	server := geocodiotest.NewServer()
+//	defer server.Close()
+//
+//	server.HandleGeocode("1109 N Highland St, Arlington VA", geocodio.Address{...})
+//	gc, err := server.Geocodio()
+//
+// The fake serves fixtures for /geocode and /reverse, both as single GET
+// lookups and batch POSTs (lists or keyed objects), filters data appends by
+// the requested fields and can inject latency, errors and rate limiting.
+// In tests NewServerT does the setup and cleanup in one call:
+//
+//	server, gc := geocodiotest.NewServerT(t, strings.NewReader(fixtures))
+package geocodiotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/strategycomplex/go-geocodio"
+)
+
+// APIKey is the key accepted by the fake server and used by Server.Geocodio
+const APIKey = "geocodiotest-key"
+
+// NoResultsError is the per-query error reported for batch queries without a fixture
+const NoResultsError = "Could not geocode address. Postal code or city required."
+
+// Request is a request received by the fake server
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   []byte
+}
+
+// Server is a fake Geocodio API backed by fixtures
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	geocodes map[string]json.RawMessage
+	reverses map[string]json.RawMessage
+	latency  time.Duration
+	faults   []fault
+	requests []Request
+}
+
+type fault struct {
+	statusCode int
+	message    string
+	retryAfter time.Duration
+}
+
+// NewServer starts a fake server without fixtures, close it when done
+func NewServer() *Server {
+	s := &Server{
+		geocodes: map[string]json.RawMessage{},
+		reverses: map[string]json.RawMessage{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// NewServerT starts a fake server serving each fixtures document, see
+// LoadFixtures, and returns it with a client for it. The server is closed
+// when the test ends and any error fails the test.
+func NewServerT(t testing.TB, fixtures ...io.Reader) (*Server, *geocodio.Geocodio) {
+	t.Helper()
+
+	s := NewServer()
+	t.Cleanup(s.Close)
+	for _, r := range fixtures {
+		if err := s.LoadFixtures(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gc, err := s.Geocodio()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, gc
+}
+
+// Geocodio creates a client for the fake server, opts are applied after the
+// API key, base URL and HTTP client options
+func (s *Server) Geocodio(opts ...geocodio.Option) (*geocodio.Geocodio, error) {
+	return geocodio.NewWithOptions(append([]geocodio.Option{
+		geocodio.WithAPIKey(APIKey),
+		geocodio.WithBaseURL(s.URL),
+		geocodio.WithHTTPClient(s.Client()),
+	}, opts...)...)
+}
+
+// HandleGeocode answers forward geocoding of query with results
+func (s *Server) HandleGeocode(query string, results ...geocodio.Address) {
+	s.setFixture(s.geocodes, normalizeQuery(query), query, results)
+}
+
+// HandleReverse answers reverse geocoding of location with results
+func (s *Server) HandleReverse(location geocodio.Location, results ...geocodio.Address) {
+	s.setFixture(s.reverses, location.String(), location.String(), results)
+}
+
+func (s *Server) setFixture(fixtures map[string]json.RawMessage, key, query string, results []geocodio.Address) {
+	if results == nil {
+		results = []geocodio.Address{}
+	}
+	payload, _ := json.Marshal(map[string]interface{}{
+		"input":   map[string]string{"formatted_address": query},
+		"results": results,
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fixtures[key] = payload
+}
+
+// SetLatency delays every response by d
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// FailNext answers the next n requests with statusCode and message
+func (s *Server) FailNext(n int, statusCode int, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.faults = append(s.faults, fault{statusCode: statusCode, message: message})
+	}
+}
+
+// RateLimitNext answers the next n requests with 429 Too Many Requests and a
+// Retry-After of retryAfter
+func (s *Server) RateLimitNext(n int, retryAfter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.faults = append(s.faults, fault{
+			statusCode: http.StatusTooManyRequests,
+			message:    "You have exceeded the rate limit",
+			retryAfter: retryAfter,
+		})
+	}
+}
+
+// Requests returns the requests received so far, including injected failures
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Reset forgets received requests and pending failures, fixtures are kept
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+	s.faults = nil
+	s.latency = 0
+}
+
+var versionPrefix = regexp.MustCompile(`^/v\d+(\.\d+)?`)
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	path := versionPrefix.ReplaceAllString(r.URL.Path, "")
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   path,
+		Query:  r.URL.Query(),
+		Body:   body,
+	})
+	latency := s.latency
+	var injected *fault
+	if len(s.faults) > 0 {
+		injected = &s.faults[0]
+		s.faults = s.faults[1:]
+	}
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if injected != nil {
+		if injected.retryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int((injected.retryAfter+time.Second-1)/time.Second)))
+		}
+		writeError(w, injected.statusCode, injected.message)
+		return
+	}
+
+	if r.URL.Query().Get("api_key") != APIKey {
+		writeError(w, http.StatusForbidden, "Invalid API key")
+		return
+	}
+
+	var lookup func(query url.Values) (json.RawMessage, bool)
+	switch path {
+	case "/geocode":
+		lookup = s.lookupGeocode
+	case "/reverse":
+		lookup = s.lookupReverse
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	fields, err := geocodio.ParseFieldSet(r.URL.Query().Get("fields"))
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	country := r.URL.Query().Get("country")
+
+	switch r.Method {
+	case http.MethodGet:
+		payload, ok := lookup(r.URL.Query())
+		if !ok {
+			writeJSON(w, map[string]interface{}{"results": []interface{}{}})
+			return
+		}
+		writeJSON(w, filterPayload(payload, fields, limit, country))
+
+	case http.MethodPost:
+		s.serveBatch(w, body, lookup, fields, limit, country)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// serveBatch answers a batch POST of query strings or address components,
+// either as a list or an object keyed by caller IDs
+func (s *Server) serveBatch(w http.ResponseWriter, body []byte, lookup func(url.Values) (json.RawMessage, bool), fields geocodio.FieldSet, limit int, country string) {
+	item := func(raw json.RawMessage) map[string]interface{} {
+		query, display := batchQuery(raw)
+		result := map[string]interface{}{"query": display}
+		if payload, ok := lookup(query); ok {
+			result["response"] = filterPayload(payload, fields, limit, country)
+		} else {
+			result["response"] = map[string]interface{}{"results": []interface{}{}, "error": NoResultsError}
+		}
+		return result
+	}
+
+	var list []json.RawMessage
+	if err := json.Unmarshal(body, &list); err == nil {
+		results := make([]interface{}, len(list))
+		for i, raw := range list {
+			results[i] = item(raw)
+		}
+		writeJSON(w, map[string]interface{}{"results": results})
+		return
+	}
+
+	var keyed map[string]json.RawMessage
+	if err := json.Unmarshal(body, &keyed); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "Could not parse batch payload")
+		return
+	}
+	results := make(map[string]interface{}, len(keyed))
+	for key, raw := range keyed {
+		results[key] = item(raw)
+	}
+	writeJSON(w, map[string]interface{}{"results": results})
+}
+
+// batchQuery converts a batch item to the parameters of a single lookup
+func batchQuery(raw json.RawMessage) (url.Values, interface{}) {
+	query := url.Values{}
+
+	var q string
+	if err := json.Unmarshal(raw, &q); err == nil {
+		query.Set("q", q)
+		return query, q
+	}
+
+	var components map[string]string
+	json.Unmarshal(raw, &components)
+	for k, v := range components {
+		query.Set(k, v)
+	}
+	return query, components
+}
+
+func (s *Server) lookupGeocode(query url.Values) (json.RawMessage, bool) {
+	q := query.Get("q")
+	if q == "" {
+		// join address components in the order they are written
+		var parts []string
+		for _, k := range []string{"street", "city", "county", "state", "postal_code", "country"} {
+			if v := query.Get(k); v != "" {
+				parts = append(parts, v)
+			}
+		}
+		q = strings.Join(parts, ", ")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	payload, ok := s.geocodes[normalizeQuery(q)]
+	return payload, ok
+}
+
+func (s *Server) lookupReverse(query url.Values) (json.RawMessage, bool) {
+	location, err := parseLocation(query.Get("q"))
+	if err != nil {
+		return nil, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	payload, ok := s.reverses[location.String()]
+	return payload, ok
+}
+
+// normalizeQuery matches queries regardless of case, punctuation and spacing
+func normalizeQuery(query string) string {
+	return strings.ToLower(strings.Join(strings.FieldsFunc(query, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '.'
+	}), " "))
+}
+
+// filterPayload keeps only the results in country, if set, with the data
+// appends selected by fields and at most limit of them, mirroring how the
+// API shapes responses
+func filterPayload(payload json.RawMessage, fields geocodio.FieldSet, limit int, country string) interface{} {
+	var response map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&response); err != nil {
+		return payload
+	}
+
+	results, _ := response["results"].([]interface{})
+	if country != "" {
+		matched := []interface{}{}
+		for _, result := range results {
+			result, _ := result.(map[string]interface{})
+			components, _ := result["address_components"].(map[string]interface{})
+			if c, _ := components["country"].(string); geocodio.NormalizeCountry(c) == country {
+				matched = append(matched, result)
+			}
+		}
+		results = matched
+	}
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	keys := map[string]bool{}
+	censusYears := map[string]bool{}
+	for _, field := range fields {
+		for _, key := range fieldKeys(field) {
+			keys[key] = true
+		}
+		if year := strings.TrimPrefix(string(field), string(geocodio.FieldCensus)); year != string(field) {
+			censusYears[year] = true
+		}
+	}
+
+	for _, result := range results {
+		result, ok := result.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		appends, _ := result["fields"].(map[string]interface{})
+		for key := range appends {
+			if !keys[key] {
+				delete(appends, key)
+			}
+		}
+		// census2020 returns only that year, census alone any year
+		if census, ok := appends["census"].(map[string]interface{}); ok && !censusYears[""] {
+			for year := range census {
+				if !censusYears[year] {
+					delete(census, year)
+				}
+			}
+		}
+		if len(appends) == 0 {
+			delete(result, "fields")
+		}
+	}
+
+	if results != nil {
+		response["results"] = results
+	}
+	return response
+}
+
+// fieldKeys are the keys a field adds to a result's "fields" object
+func fieldKeys(field geocodio.Field) []string {
+	name := string(field)
+	switch {
+	case name == "stateleg":
+		return []string{"state_legislative_districts"}
+	case name == "stateleg-next":
+		return []string{"state_legislative_districts_next"}
+	case name == "school":
+		return []string{"school_districts"}
+	case strings.HasPrefix(name, "cd"):
+		return []string{"congressional_districts", "congressional_district"}
+	case strings.HasPrefix(name, "census"):
+		return []string{"census"}
+	case strings.HasPrefix(name, "acs-"):
+		return []string{"acs"}
+	}
+	return []string{name}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
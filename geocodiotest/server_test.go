@@ -0,0 +1,146 @@
+package geocodiotest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/strategycomplex/go-geocodio"
+	"github.com/strategycomplex/go-geocodio/geocodiotest"
+)
+
+const highland = "1109 N Highland St, Arlington VA"
+
+func newServer(t *testing.T) *geocodiotest.Server {
+	server := geocodiotest.NewServer()
+	t.Cleanup(server.Close)
+	if err := server.LoadFixtureFile("testdata/fixtures.json"); err != nil {
+		t.Fatal(err)
+	}
+	return server
+}
+
+func TestGeocodeFixture(t *testing.T) {
+	server := newServer(t)
+	gc, err := server.Geocodio()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := gc.Geocode("1109 n highland st,  arlington, va")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Results[0].Location.Latitude != 38.886672 {
+		t.Error("Unexpected location", result.Results[0].Location)
+	}
+	if result.Results[0].Fields.Timezone.Name != "" {
+		t.Error("Expected no timezone without requesting it", result.Results[0].Fields.Timezone)
+	}
+
+	result, err = gc.GeocodeAndReturnTimezone(highland)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Results[0].Fields.Timezone.Name != "America/New_York" || len(result.Results[0].Fields.CongressionalDistricts) != 0 {
+		t.Error("Expected only the timezone field", result.Results[0].Fields)
+	}
+
+	_, err = gc.Geocode("123 Nonsense Ln, Nowhere, XX")
+	if err != geocodio.ErrNoResultsFound {
+		t.Error("Expected error", geocodio.ErrNoResultsFound, "but saw", err)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 3 || requests[1].Path != "/geocode" || requests[1].Query.Get("fields") != "timezone" {
+		t.Error("Unexpected requests", requests)
+	}
+}
+
+func TestBatchFixtures(t *testing.T) {
+	server := newServer(t)
+	server.HandleReverse(geocodio.Location{Latitude: 1, Longitude: 2}, geocodio.Address{Formatted: "Somewhere"})
+	gc, err := server.Geocodio()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := gc.GeocodeBatch(highland, "123 Nonsense Ln, Nowhere, XX")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Results[0].Response.Results[0].Formatted != "1109 N Highland St, Arlington, VA 22201" {
+		t.Error("Unexpected result", resp.Results[0])
+	}
+	if resp.Results[1].Response.Error != geocodiotest.NoResultsError {
+		t.Error("Expected a per-query error", resp.Results[1])
+	}
+
+	results, err := gc.ReverseBatchMap(map[string]geocodio.Location{
+		"office": {Latitude: 38.886672, Longitude: -77.094735},
+		"other":  {Latitude: 1, Longitude: 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results["office"].Results[0].Accuracy != 1 || results["other"].Results[0].Formatted != "Somewhere" {
+		t.Error("Unexpected results", results)
+	}
+}
+
+func TestInjectedFailures(t *testing.T) {
+	server := newServer(t)
+	gc, err := server.Geocodio()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server.FailNext(1, http.StatusInternalServerError, "Boom")
+	_, err = gc.Geocode(highland)
+	if !errors.Is(err, geocodio.ErrServer) {
+		t.Error("Expected error", geocodio.ErrServer, "but saw", err)
+	}
+
+	server.RateLimitNext(1, 2*time.Second)
+	_, err = gc.Geocode(highland)
+	var apiErr *geocodio.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests || apiErr.RetryAfter != 2*time.Second {
+		t.Error("Expected a rate limit error but saw", err)
+	}
+
+	server.SetLatency(time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = gc.GeocodeContext(ctx, highland)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("Expected error", context.DeadlineExceeded, "but saw", err)
+	}
+
+	server.Reset()
+	if _, err := gc.Geocode(highland); err != nil {
+		t.Error(err)
+	}
+	if len(server.Requests()) != 1 {
+		t.Error("Expected 1 request after reset but saw", len(server.Requests()))
+	}
+}
+
+func TestNewServerT(t *testing.T) {
+	fixtures, err := os.Open("testdata/fixtures.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fixtures.Close()
+
+	server, gc := geocodiotest.NewServerT(t, fixtures)
+	result, err := gc.Geocode(highland)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Results[0].Location.Latitude != 38.886672 || len(server.Requests()) != 1 {
+		t.Error("Unexpected result", result.Results[0].Location)
+	}
+}
@@ -0,0 +1,255 @@
+package geocodio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrGeoJSONNoPoints is returned when GeoJSON input contains no Point geometries
+var ErrGeoJSONNoPoints = errors.New("GeoJSON contains no points")
+
+// GeoJSON object types
+const (
+	GeoJSONFeatureCollection = "FeatureCollection"
+	GeoJSONFeature           = "Feature"
+	GeoJSONPoint             = "Point"
+)
+
+// FeatureCollection is a GeoJSON feature collection, see RFC 7946
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// Feature is a GeoJSON feature, Geometry is nil for queries without results
+type Feature struct {
+	Type       string                 `json:"type"`
+	Geometry   *Geometry              `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// Geometry is a GeoJSON geometry, only points are produced and read
+type Geometry struct {
+	Type string `json:"type"`
+	// Coordinates of a point are [longitude, latitude]
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// PointGeometry returns a GeoJSON Point at location
+func PointGeometry(location Location) *Geometry {
+	coordinates, _ := json.Marshal([]float64{location.Longitude, location.Latitude})
+	return &Geometry{Type: GeoJSONPoint, Coordinates: coordinates}
+}
+
+// Location returns the location of a Point geometry
+func (g Geometry) Location() (Location, error) {
+	if g.Type != GeoJSONPoint {
+		return Location{}, fmt.Errorf("GeoJSON geometry %q is not a Point", g.Type)
+	}
+
+	position := []float64{}
+	if err := json.Unmarshal(g.Coordinates, &position); err != nil {
+		return Location{}, err
+	}
+	if len(position) < 2 {
+		return Location{}, errors.New("GeoJSON Point must have a longitude and latitude")
+	}
+	return Location{Latitude: position[1], Longitude: position[0]}, nil
+}
+
+// ToGeoJSON returns a Point feature for every result with its address
+// components, accuracy and requested fields flattened into properties
+func (self GeocodeResult) ToGeoJSON() FeatureCollection {
+	fc := FeatureCollection{Type: GeoJSONFeatureCollection, Features: []Feature{}}
+	for _, result := range self.Results {
+		fc.Features = append(fc.Features, addressFeature(result.Address))
+	}
+	return fc
+}
+
+// ToGeoJSON returns a Point feature for every result of every query, with the
+// query and its index in the batch as properties. Queries without results are
+// included as features without geometry and with their error.
+func (self BatchResponse) ToGeoJSON() FeatureCollection {
+	fc := FeatureCollection{Type: GeoJSONFeatureCollection, Features: []Feature{}}
+	for i, result := range self.Results {
+		if len(result.Response.Results) == 0 {
+			message := result.Response.Error
+			if message == "" {
+				message = ErrNoResultsFound.Error()
+			}
+			fc.Features = append(fc.Features, Feature{
+				Type: GeoJSONFeature,
+				Properties: map[string]interface{}{
+					"query":       result.Query,
+					"query_index": i,
+					"error":       message,
+				},
+			})
+			continue
+		}
+
+		for _, address := range result.Response.Results {
+			feature := addressFeature(address)
+			feature.Properties["query"] = result.Query
+			feature.Properties["query_index"] = i
+			fc.Features = append(fc.Features, feature)
+		}
+	}
+	return fc
+}
+
+// Points returns the locations of the Point features in order
+func (fc FeatureCollection) Points() ([]Location, error) {
+	var points []Location
+	for i, feature := range fc.Features {
+		if feature.Geometry == nil {
+			continue
+		}
+		location, err := feature.Geometry.Location()
+		if err != nil {
+			return nil, fmt.Errorf("feature %d: %w", i, err)
+		}
+		points = append(points, location)
+	}
+	return points, nil
+}
+
+// ParseGeoJSONPoints reads the points of a FeatureCollection, a single
+// Feature or a bare Point geometry
+func ParseGeoJSONPoints(data []byte) ([]Location, error) {
+	object := struct {
+		Type string `json:"type"`
+	}{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+
+	var fc FeatureCollection
+	switch object.Type {
+	case GeoJSONFeatureCollection:
+		if err := json.Unmarshal(data, &fc); err != nil {
+			return nil, err
+		}
+	case GeoJSONFeature:
+		feature := Feature{}
+		if err := json.Unmarshal(data, &feature); err != nil {
+			return nil, err
+		}
+		fc.Features = []Feature{feature}
+	default:
+		geometry := Geometry{}
+		if err := json.Unmarshal(data, &geometry); err != nil {
+			return nil, err
+		}
+		fc.Features = []Feature{{Geometry: &geometry}}
+	}
+
+	points, err := fc.Points()
+	if err != nil {
+		return nil, err
+	}
+	if len(points) == 0 {
+		return nil, ErrGeoJSONNoPoints
+	}
+	return points, nil
+}
+
+// ReverseGeoJSON reverse geocodes the points of GeoJSON data in API sized
+// batches, results are in the order of the points, see ParseGeoJSONPoints
+func (g *Geocodio) ReverseGeoJSON(opts ChunkOptions, data []byte) (BatchResponse, error) {
+	return g.ReverseGeoJSONContext(context.Background(), opts, data)
+}
+
+// ReverseGeoJSONContext is like ReverseGeoJSON but aborts pending requests when ctx is cancelled
+func (g *Geocodio) ReverseGeoJSONContext(ctx context.Context, opts ChunkOptions, data []byte) (BatchResponse, error) {
+	points, err := ParseGeoJSONPoints(data)
+	if err != nil {
+		return BatchResponse{}, err
+	}
+
+	latlngs := make([]float64, 0, 2*len(points))
+	for _, point := range points {
+		latlngs = append(latlngs, point.Latitude, point.Longitude)
+	}
+	return g.ReverseBatchChunkedContext(ctx, opts, latlngs...)
+}
+
+func addressFeature(address Address) Feature {
+	properties := map[string]interface{}{
+		"formatted_address": address.Formatted,
+		"accuracy":          address.Accuracy,
+		"accuracy_type":     address.AccuracyType,
+	}
+	if address.Source != "" {
+		properties["source"] = address.Source
+	}
+
+	components := map[string]interface{}{}
+	flattenJSON(components, "", address.Components)
+	for k, v := range components {
+		if v != "" {
+			properties["address_components."+k] = v
+		}
+	}
+
+	// only the fields the API returned, skipping the v1.0 duplicate of the
+	// congressional districts
+	fields := reflect.ValueOf(address.Fields)
+	for i := 0; i < fields.NumField(); i++ {
+		name := strings.Split(fields.Type().Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || name == "congressional_district" || fields.Field(i).IsZero() {
+			continue
+		}
+		flattenJSON(properties, "fields."+name, fields.Field(i).Interface())
+	}
+
+	return Feature{
+		Type:       GeoJSONFeature,
+		Geometry:   PointGeometry(address.Location),
+		Properties: properties,
+	}
+}
+
+// flattenJSON adds the JSON encoding of v to properties, nested objects and
+// arrays are joined into dotted keys such as fields.timezone.name
+func flattenJSON(properties map[string]interface{}, prefix string, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return
+	}
+	flatten(properties, prefix, decoded)
+}
+
+func flatten(properties map[string]interface{}, prefix string, v interface{}) {
+	key := func(k string) string {
+		if prefix == "" {
+			return k
+		}
+		return prefix + "." + k
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, value := range v {
+			flatten(properties, key(k), value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			flatten(properties, key(strconv.Itoa(i)), value)
+		}
+	case nil:
+	default:
+		properties[prefix] = v
+	}
+}
@@ -0,0 +1,113 @@
+package geocodio_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/strategycomplex/go-geocodio"
+	"github.com/strategycomplex/go-geocodio/geocodiotest"
+)
+
+func TestGeocodeResultToGeoJSON(t *testing.T) {
+	result := geocodio.GeocodeResult{}
+	err := json.Unmarshal([]byte(`{"results": [{
+		"address_components": {"number": "1109", "city": "Arlington", "state": "VA"},
+		"formatted_address": "1109 N Highland St, Arlington, VA 22201",
+		"location": {"lat": 38.886672, "lng": -77.094735},
+		"accuracy": 1,
+		"accuracy_type": "rooftop",
+		"fields": {"timezone": {"name": "America/New_York", "utc_offset": -5}}
+	}]}`), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(result.ToGeoJSON())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fc := struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Geometry struct {
+				Type        string    `json:"type"`
+				Coordinates []float64 `json:"coordinates"`
+			} `json:"geometry"`
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"features"`
+	}{}
+	if err := json.Unmarshal(data, &fc); err != nil {
+		t.Fatal(err)
+	}
+
+	if fc.Type != "FeatureCollection" || len(fc.Features) != 1 {
+		t.Fatal("Unexpected feature collection", string(data))
+	}
+	feature := fc.Features[0]
+	if feature.Geometry.Type != "Point" || feature.Geometry.Coordinates[0] != AddressTestOneLongitude || feature.Geometry.Coordinates[1] != AddressTestOneLatitude {
+		t.Error("Unexpected geometry", feature.Geometry)
+	}
+	for key, want := range map[string]interface{}{
+		"accuracy_type":              "rooftop",
+		"address_components.city":    "Arlington",
+		"fields.timezone.name":       "America/New_York",
+		"fields.timezone.utc_offset": -5.0,
+	} {
+		if feature.Properties[key] != want {
+			t.Errorf("Property %s is %v, expected %v", key, feature.Properties[key], want)
+		}
+	}
+	if _, ok := feature.Properties["fields.zip4.city_delivery"]; ok {
+		t.Error("Expected fields that were not returned to be omitted")
+	}
+}
+
+func TestBatchResponseToGeoJSON(t *testing.T) {
+	resp := geocodio.BatchResponse{Results: []geocodio.BatchResult{
+		{Query: "a", Response: geocodio.BatchResultItem{Results: []geocodio.Address{{Formatted: "A"}, {Formatted: "A2"}}}},
+		{Query: "b", Response: geocodio.BatchResultItem{Error: "Could not geocode address"}},
+	}}
+
+	fc := resp.ToGeoJSON()
+	if len(fc.Features) != 3 {
+		t.Fatal("Expected 3 features but saw", len(fc.Features))
+	}
+	if fc.Features[1].Properties["query"] != "a" || fc.Features[1].Properties["formatted_address"] != "A2" {
+		t.Error("Unexpected feature", fc.Features[1])
+	}
+	if fc.Features[2].Geometry != nil || fc.Features[2].Properties["error"] != "Could not geocode address" || fc.Features[2].Properties["query_index"] != 1 {
+		t.Error("Expected a feature without geometry", fc.Features[2])
+	}
+}
+
+func TestReverseGeoJSON(t *testing.T) {
+	server, gc := geocodiotest.NewServerT(t)
+	server.HandleReverse(geocodio.Location{Latitude: AddressTestOneLatitude, Longitude: AddressTestOneLongitude}, geocodio.Address{Formatted: AddressTestOneFull})
+
+	resp, err := gc.ReverseGeoJSON(geocodio.ChunkOptions{}, []byte(`{
+		"type": "FeatureCollection",
+		"features": [
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [-77.094735, 38.886672]}, "properties": {}},
+			{"type": "Feature", "geometry": null, "properties": {}},
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [2, 1]}, "properties": {}}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Results) != 2 || resp.Results[0].Response.Results[0].Formatted != AddressTestOneFull || resp.Results[1].Response.Error == "" {
+		t.Error("Unexpected results", resp.Results)
+	}
+
+	_, err = gc.ReverseGeoJSON(geocodio.ChunkOptions{}, []byte(`{"type": "LineString", "coordinates": [[1, 2], [3, 4]]}`))
+	if err == nil {
+		t.Error("Expected an error for a LineString")
+	}
+
+	_, err = gc.ReverseGeoJSON(geocodio.ChunkOptions{}, []byte(`{"type": "FeatureCollection", "features": []}`))
+	if !errors.Is(err, geocodio.ErrGeoJSONNoPoints) {
+		t.Error("Expected error", geocodio.ErrGeoJSONNoPoints, "but saw", err)
+	}
+}
@@ -0,0 +1,338 @@
+package geocodio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrListFileIsEmpty error when a list is created without a file
+	ErrListFileIsEmpty = errors.New("List file must not be empty")
+	// ErrListFailed error when the API could not process a list
+	ErrListFailed = errors.New("List processing failed")
+	// ErrInvalidListID error
+	ErrInvalidListID = errors.New("List ID must be a positive number")
+)
+
+// ListDirection is the kind of lookup performed for every row of a list
+type ListDirection string
+
+const (
+	ListForward ListDirection = "forward"
+	ListReverse ListDirection = "reverse"
+)
+
+const (
+	ListStateProcessing = "PROCESSING"
+	ListStateCompleted  = "COMPLETED"
+	ListStateFailed     = "FAILED"
+)
+
+// CreateListInput describes a spreadsheet to upload
+type CreateListInput struct {
+	// Filename is reported back by the API, such as "customers.csv"
+	Filename string
+	// File is the CSV content, it is read into memory so the upload can be retried
+	File io.Reader
+	// Direction defaults to ListForward
+	Direction ListDirection
+	// Format tells the API which columns make up the query, such as
+	// "{{A}} {{B}} {{C}} {{D}}" or "{{B}},{{C}}" for reverse lists
+	Format string
+	// Callback is an optional URL the API calls when the list is processed
+	Callback string
+	Fields   FieldSet
+}
+
+// List is a spreadsheet uploaded to the Lists API
+/*
+{
+	"id": 11950669,
+	"fields": [],
+	"file": {
+		"estimated_rows_count": 4,
+		"filename": "sample_list.csv"
+	},
+	"status": {
+		"state": "COMPLETED",
+		"progress": 100,
+		"message": "Completed",
+		"time_left_description": null,
+		"time_left_seconds": null
+	},
+	"download_url": "https://api.geocod.io/v1.6/lists/11950669/download",
+	"expires_at": "2024-03-05T21:09:41.000000Z"
+}
+*/
+type List struct {
+	ID          int        `json:"id"`
+	Fields      []string   `json:"fields"`
+	File        ListFile   `json:"file"`
+	Status      ListStatus `json:"status"`
+	DownloadURL string     `json:"download_url"`
+	ExpiresAt   string     `json:"expires_at"`
+}
+
+type ListFile struct {
+	Headers            []string `json:"headers,omitempty"`
+	EstimatedRowsCount int      `json:"estimated_rows_count"`
+	Filename           string   `json:"filename"`
+}
+
+type ListStatus struct {
+	State               string  `json:"state"`
+	Progress            float64 `json:"progress"`
+	Message             string  `json:"message"`
+	TimeLeftDescription string  `json:"time_left_description"`
+	TimeLeftSeconds     int     `json:"time_left_seconds"`
+}
+
+// Done reports whether the list finished processing, successfully or not
+func (s ListStatus) Done() bool {
+	return s.State == ListStateCompleted || s.State == ListStateFailed
+}
+
+// ListPage is one page of the lists on an account
+type ListPage struct {
+	CurrentPage int    `json:"current_page"`
+	Data        []List `json:"data"`
+	From        int    `json:"from"`
+	To          int    `json:"to"`
+	PerPage     int    `json:"per_page"`
+	NextPageURL string `json:"next_page_url"`
+	PrevPageURL string `json:"prev_page_url"`
+}
+
+// HasNextPage reports whether ListLists has more pages after this one
+func (p ListPage) HasNextPage() bool {
+	return p.NextPageURL != ""
+}
+
+// CreateList uploads a spreadsheet to be geocoded asynchronously
+// See: https://www.geocod.io/docs/#lists-api
+func (g *Geocodio) CreateList(input CreateListInput) (List, error) {
+	return g.CreateListContext(context.Background(), input)
+}
+
+// CreateListContext is like CreateList but aborts the upload when ctx is cancelled
+func (g *Geocodio) CreateListContext(ctx context.Context, input CreateListInput) (List, error) {
+	list := List{}
+	if input.File == nil {
+		return list, ErrListFileIsEmpty
+	}
+
+	if err := input.Fields.Validate(); err != nil {
+		return list, err
+	}
+
+	if err := g.checkFieldVersions(input.Fields); err != nil {
+		return list, err
+	}
+
+	direction := input.Direction
+	if direction == "" {
+		direction = ListForward
+	}
+
+	filename := input.Filename
+	if filename == "" {
+		filename = "list.csv"
+	}
+
+	var (
+		body   bytes.Buffer
+		writer = multipart.NewWriter(&body)
+	)
+
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return list, err
+	}
+	n, err := io.Copy(part, input.File)
+	if err != nil {
+		return list, err
+	}
+	if n == 0 {
+		return list, ErrListFileIsEmpty
+	}
+
+	params := [][2]string{
+		{"direction", string(direction)},
+		{"format", input.Format},
+		{"callback", input.Callback},
+		{"fields", input.Fields.String()},
+	}
+	for _, param := range params {
+		if param[1] == "" {
+			continue
+		}
+		if err := writer.WriteField(param[0], param[1]); err != nil {
+			return list, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return list, err
+	}
+
+	err = g.callBody(ctx, MethodPost, "/lists", body.Bytes(), writer.FormDataContentType(), nil, &jsonResult{&list})
+	return list, err
+}
+
+// GetListStatus looks up a list and its processing status
+func (g *Geocodio) GetListStatus(id int) (List, error) {
+	return g.GetListStatusContext(context.Background(), id)
+}
+
+// GetListStatusContext is like GetListStatus but aborts the request when ctx is cancelled
+func (g *Geocodio) GetListStatusContext(ctx context.Context, id int) (List, error) {
+	list := List{}
+	if id <= 0 {
+		return list, ErrInvalidListID
+	}
+
+	err := g.get(ctx, "/lists/"+strconv.Itoa(id), nil, &jsonResult{&list})
+	return list, err
+}
+
+// ListLists returns a page of the lists on the account, starting at page 1
+func (g *Geocodio) ListLists(page int) (ListPage, error) {
+	return g.ListListsContext(context.Background(), page)
+}
+
+// ListListsContext is like ListLists but aborts the request when ctx is cancelled
+func (g *Geocodio) ListListsContext(ctx context.Context, page int) (ListPage, error) {
+	resp := ListPage{}
+	if page < 1 {
+		page = 1
+	}
+
+	err := g.get(ctx, "/lists", map[string]string{"page": strconv.Itoa(page)}, &jsonResult{&resp})
+	return resp, err
+}
+
+// DownloadList streams the geocoded spreadsheet to w and returns the number
+// of bytes written. The client's request timeout only applies until the
+// download starts, so large spreadsheets are not cut off; bound the whole
+// download with the context of DownloadListContext.
+func (g *Geocodio) DownloadList(id int, w io.Writer) (int64, error) {
+	return g.DownloadListContext(context.Background(), id, w)
+}
+
+// DownloadListContext is like DownloadList but aborts the download when ctx is cancelled
+func (g *Geocodio) DownloadListContext(ctx context.Context, id int, w io.Writer) (int64, error) {
+	if id <= 0 {
+		return 0, ErrInvalidListID
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	u, err := g.requestURL("/lists/"+strconv.Itoa(id)+"/download", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := g.do(context.WithValue(ctx, headerTimeoutKey{}, true), MethodGet, u, nil, "")
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return 0, newAPIError(u.String(), resp.Status, resp.StatusCode, resp.Header, body)
+	}
+
+	return io.Copy(w, resp.Body)
+}
+
+// DeleteList removes a list and its geocoded spreadsheet
+func (g *Geocodio) DeleteList(id int) error {
+	return g.DeleteListContext(context.Background(), id)
+}
+
+// DeleteListContext is like DeleteList but aborts the request when ctx is cancelled
+func (g *Geocodio) DeleteListContext(ctx context.Context, id int) error {
+	if id <= 0 {
+		return ErrInvalidListID
+	}
+
+	resp := struct {
+		Success bool `json:"success"`
+	}{}
+
+	return g.call(ctx, MethodDelete, "/lists/"+strconv.Itoa(id), nil, nil, &jsonResult{&resp})
+}
+
+// ListWaitOptions controls how WaitForList polls
+type ListWaitOptions struct {
+	// Interval is the first wait between polls, defaults to 2s
+	Interval time.Duration
+	// MaxInterval caps the wait as it grows by half on every poll, defaults to 1m
+	MaxInterval time.Duration
+	// OnProgress, if set, is called with every status received
+	OnProgress func(List)
+}
+
+// WaitForList polls a list until it has been processed. A failed list is
+// returned with an error wrapping ErrListFailed.
+func (g *Geocodio) WaitForList(id int, opts ListWaitOptions) (List, error) {
+	return g.WaitForListContext(context.Background(), id, opts)
+}
+
+// WaitForListContext is like WaitForList but stops polling when ctx is cancelled
+func (g *Geocodio) WaitForListContext(ctx context.Context, id int, opts ListWaitOptions) (List, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = time.Minute
+	}
+
+	for {
+		list, err := g.GetListStatusContext(ctx, id)
+		if err != nil {
+			return list, err
+		}
+
+		if opts.OnProgress != nil {
+			opts.OnProgress(list)
+		}
+
+		if list.Status.State == ListStateFailed {
+			return list, fmt.Errorf("%w: %s", ErrListFailed, list.Status.Message)
+		}
+		if list.Status.Done() {
+			return list, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return list, ctx.Err()
+		case <-timer.C:
+		}
+
+		interval += interval / 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package geocodio_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/strategycomplex/go-geocodio"
+)
+
+const listsTestCSV = "address,city,state\n1109 N Highland St,Arlington,VA\n"
+
+func newListsServer(t *testing.T, polls *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/lists":
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Error(err)
+			}
+			file, header, err := r.FormFile("file")
+			if err != nil {
+				t.Fatal(err)
+			}
+			content, _ := ioutil.ReadAll(file)
+			if string(content) != listsTestCSV || header.Filename != "customers.csv" {
+				t.Error("Unexpected upload", header.Filename, string(content))
+			}
+			if r.FormValue("direction") != "forward" || r.FormValue("format") != "{{A}} {{B}} {{C}}" || r.FormValue("fields") != "timezone" {
+				t.Error("Unexpected form", r.Form)
+			}
+			w.Write([]byte(`{"id": 42, "file": {"headers": ["address", "city", "state"], "estimated_rows_count": 1, "filename": "customers.csv"}}`))
+
+		case r.Method == http.MethodGet && r.URL.Path == "/lists/42":
+			*polls++
+			state, progress := "PROCESSING", 50
+			if *polls >= 3 {
+				state, progress = "COMPLETED", 100
+			}
+			w.Write([]byte(`{"id": 42, "status": {"state": "` + state + `", "progress": ` + strconv.Itoa(progress) + `, "message": "ok"}}`))
+
+		case r.Method == http.MethodGet && r.URL.Path == "/lists/7":
+			w.Write([]byte(`{"id": 7, "status": {"state": "FAILED", "message": "Could not parse file"}}`))
+
+		case r.Method == http.MethodGet && r.URL.Path == "/lists":
+			if r.URL.Query().Get("page") == "1" {
+				w.Write([]byte(`{"current_page": 1, "data": [{"id": 42}], "per_page": 1, "next_page_url": "https://api.geocod.io/v1.6/lists?page=2"}`))
+				return
+			}
+			w.Write([]byte(`{"current_page": 2, "data": [{"id": 7}], "per_page": 1, "next_page_url": null}`))
+
+		case r.Method == http.MethodGet && r.URL.Path == "/lists/42/download":
+			w.Header().Set("Content-Type", "text/csv")
+			w.Write([]byte(listsTestCSV))
+
+		case r.Method == http.MethodDelete && r.URL.Path == "/lists/42":
+			w.Write([]byte(`{"success": true}`))
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "Not found"}`))
+		}
+	}))
+}
+
+func TestLists(t *testing.T) {
+	var polls int
+	server := newListsServer(t, &polls)
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := gc.CreateList(geocodio.CreateListInput{
+		Filename: "customers.csv",
+		File:     strings.NewReader(listsTestCSV),
+		Format:   "{{A}} {{B}} {{C}}",
+		Fields:   geocodio.NewFieldSet(geocodio.FieldTimezone),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.ID != 42 || list.File.EstimatedRowsCount != 1 {
+		t.Error("Unexpected list", list)
+	}
+
+	var progress []float64
+	list, err = gc.WaitForList(list.ID, geocodio.ListWaitOptions{
+		Interval: time.Millisecond,
+		OnProgress: func(l geocodio.List) {
+			progress = append(progress, l.Status.Progress)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Status.State != geocodio.ListStateCompleted || len(progress) != 3 || progress[2] != 100 {
+		t.Error("Unexpected status", list.Status, progress)
+	}
+
+	page, err := gc.ListLists(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !page.HasNextPage() || page.Data[0].ID != 42 {
+		t.Error("Unexpected first page", page)
+	}
+	page, err = gc.ListLists(page.CurrentPage + 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.HasNextPage() || page.Data[0].ID != 7 {
+		t.Error("Unexpected second page", page)
+	}
+
+	var out bytes.Buffer
+	n, err := gc.DownloadList(42, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != listsTestCSV || n != int64(len(listsTestCSV)) {
+		t.Error("Unexpected download", n, out.String())
+	}
+
+	if err := gc.DeleteList(42); err != nil {
+		t.Error(err)
+	}
+
+	_, err = gc.DownloadList(99, &out)
+	var apiErr *geocodio.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Error("Expected a 404 APIError but saw", err)
+	}
+}
+
+func TestWaitForListFailed(t *testing.T) {
+	var polls int
+	server := newListsServer(t, &polls)
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = gc.WaitForList(7, geocodio.ListWaitOptions{})
+	if !errors.Is(err, geocodio.ErrListFailed) {
+		t.Error("Expected error", geocodio.ErrListFailed, "but saw", err)
+	}
+}
+
+func TestWaitForListCancelled(t *testing.T) {
+	var polls int
+	server := newListsServer(t, &polls)
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = gc.WaitForListContext(ctx, 42, geocodio.ListWaitOptions{Interval: time.Second})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("Expected error", context.DeadlineExceeded, "but saw", err)
+	}
+}
+
+func TestDownloadListSlowBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/lists/7/download" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte("address,city,state\n"))
+		w.(http.Flusher).Flush()
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte("1109 N Highland St,Arlington,VA\n"))
+	}))
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(
+		geocodio.WithAPIKey("test-key"),
+		geocodio.WithBaseURL(server.URL),
+		geocodio.WithTimeout(100*time.Millisecond),
+		geocodio.WithRetryPolicy(geocodio.RetryPolicy{MaxAttempts: 1}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the timeout stops once the download has started
+	out := &bytes.Buffer{}
+	if _, err := gc.DownloadList(42, out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != listsTestCSV {
+		t.Error("Expected the whole download but saw", out.String())
+	}
+
+	// but still applies while waiting for it to start
+	_, err = gc.DownloadList(7, &bytes.Buffer{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("Expected error", context.DeadlineExceeded, "but saw", err)
+	}
+	if err != nil && strings.Contains(err.Error(), "test-key") {
+		t.Error("Expected the API key to be redacted from", err)
+	}
+}
@@ -1,6 +1,36 @@
 package geocodio
 
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
 type Location struct {
 	Latitude  float64 `json:"lat"`
 	Longitude float64 `json:"lng"`
 }
+
+// String formats the location as a "lat,lng" query
+func (l Location) String() string {
+	return strconv.FormatFloat(l.Latitude, 'f', 9, 64) + "," + strconv.FormatFloat(l.Longitude, 'f', 9, 64)
+}
+
+// UnmarshalJSON accepts a {"lat": ..., "lng": ...} object as well as the
+// [lat, lng] pair used by the distance endpoints
+func (l *Location) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '[' {
+		pair := []float64{}
+		if err := json.Unmarshal(data, &pair); err != nil {
+			return err
+		}
+		if len(pair) != 2 {
+			return errors.New("Location must be a [lat, lng] pair")
+		}
+		l.Latitude, l.Longitude = pair[0], pair[1]
+		return nil
+	}
+
+	type location Location
+	return json.Unmarshal(data, (*location)(l))
+}
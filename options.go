@@ -0,0 +1,111 @@
+package geocodio
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTimeout is applied to each request unless overridden with WithTimeout
+	DefaultTimeout = 10 * time.Second
+	// DefaultUserAgent is sent with each request unless overridden with WithUserAgent
+	DefaultUserAgent = "go-geocodio"
+)
+
+// Option configures a Geocodio client, see NewWithOptions
+type Option func(*Geocodio) error
+
+// WithAPIKey sets the API key instead of reading it from the environment
+func WithAPIKey(apiKey string) Option {
+	return func(g *Geocodio) error {
+		if strings.TrimSpace(apiKey) == "" {
+			return ErrMissingAPIKey
+		}
+		g.APIKey = apiKey
+		return nil
+	}
+}
+
+// WithHTTPClient uses the provided client for every request, which allows
+// sharing a pooled transport across clients
+func WithHTTPClient(client *http.Client) Option {
+	return func(g *Geocodio) error {
+		if client == nil {
+			return errors.New("HTTP client must not be nil")
+		}
+		g.httpClient = client
+		return nil
+	}
+}
+
+// WithBaseURL points the client at a different API root, for example a
+// local stand-in server in tests. The URL should include the version path.
+func WithBaseURL(baseURL string) Option {
+	return func(g *Geocodio) error {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return errors.New("Base URL must be absolute: " + baseURL)
+		}
+		g.baseURL = strings.TrimRight(baseURL, "/")
+		return nil
+	}
+}
+
+// WithTimeout bounds each request, a zero duration disables the timeout.
+// List downloads are only bounded until their response headers arrive.
+func WithTimeout(timeout time.Duration) Option {
+	return func(g *Geocodio) error {
+		if timeout < 0 {
+			return errors.New("Timeout must not be negative")
+		}
+		g.timeout = timeout
+		g.timeoutSet = true
+		return nil
+	}
+}
+
+// WithUserAgent overrides the User-Agent header sent with each request
+func WithUserAgent(userAgent string) Option {
+	return func(g *Geocodio) error {
+		g.userAgent = userAgent
+		return nil
+	}
+}
+
+// defaultHTTPClient is shared by clients that do not provide their own so
+// connections are pooled across requests
+var defaultHTTPClient = &http.Client{}
+
+func (g *Geocodio) client() *http.Client {
+	if g.httpClient != nil {
+		return g.httpClient
+	}
+	return defaultHTTPClient
+}
+
+func (g *Geocodio) apiBaseURL() string {
+	if g.baseURL != "" {
+		return g.baseURL
+	}
+	return GeocodioAPIHost + "/" + string(g.APIVersion())
+}
+
+func (g *Geocodio) requestTimeout() time.Duration {
+	if g.timeoutSet {
+		return g.timeout
+	}
+	return DefaultTimeout
+}
+
+func (g *Geocodio) userAgentHeader() string {
+	if g.userAgent != "" {
+		return g.userAgent
+	}
+	return DefaultUserAgent
+}
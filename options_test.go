@@ -0,0 +1,106 @@
+package geocodio_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/strategycomplex/go-geocodio"
+)
+
+const optionsTestResponse = `{
+	"input": {"formatted_address": "1109 N Highland St, Arlington, VA 22201"},
+	"results": [{
+		"formatted_address": "1109 N Highland St, Arlington, VA 22201",
+		"location": {"lat": 38.886672, "lng": -77.094735},
+		"accuracy": 1,
+		"accuracy_type": "rooftop"
+	}]
+}`
+
+func TestNewWithOptionsStandInServer(t *testing.T) {
+	var (
+		userAgent string
+		apiKey    string
+		path      string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.UserAgent()
+		apiKey = r.URL.Query().Get("api_key")
+		path = r.URL.Path
+		w.Write([]byte(optionsTestResponse))
+	}))
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(
+		geocodio.WithAPIKey("test-key"),
+		geocodio.WithBaseURL(server.URL+"/v1.6/"),
+		geocodio.WithHTTPClient(server.Client()),
+		geocodio.WithUserAgent("options-test"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := gc.Geocode(AddressTestOneFull)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Results[0].Location.Latitude != AddressTestOneLatitude {
+		t.Errorf("Location latitude %f does not match %f", result.Results[0].Location.Latitude, AddressTestOneLatitude)
+	}
+	if userAgent != "options-test" {
+		t.Error("Expected user agent options-test but saw", userAgent)
+	}
+	if apiKey != "test-key" {
+		t.Error("Expected api key test-key but saw", apiKey)
+	}
+	if path != "/v1.6/geocode" {
+		t.Error("Expected path /v1.6/geocode but saw", path)
+	}
+}
+
+func TestNewWithOptionsTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(
+		geocodio.WithAPIKey("test-key"),
+		geocodio.WithBaseURL(server.URL),
+		geocodio.WithTimeout(10*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = gc.Geocode(AddressTestOneFull)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("Expected error", context.DeadlineExceeded, "but saw", err)
+	}
+}
+
+func TestNewWithOptionsInvalid(t *testing.T) {
+	_, err := geocodio.NewWithOptions(geocodio.WithAPIKey(""))
+	if err != geocodio.ErrMissingAPIKey {
+		t.Error("Expected error", geocodio.ErrMissingAPIKey, "but saw", err)
+	}
+
+	_, err = geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithBaseURL("/v1.6"))
+	if err == nil {
+		t.Error("Expected error for relative base URL")
+	}
+
+	_, err = geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithHTTPClient(nil))
+	if err == nil {
+		t.Error("Expected error for nil HTTP client")
+	}
+}
@@ -0,0 +1,137 @@
+package geocodio
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrRateLimitWaitExceedsDeadline is returned when waiting for the client-side
+// rate limiter would outlast the caller's context deadline
+var ErrRateLimitWaitExceedsDeadline = errors.New("Rate limit wait would exceed context deadline")
+
+// ErrInvalidRateLimit is returned for a rate that is not a positive number of requests per second
+var ErrInvalidRateLimit = errors.New("Rate limit must be a positive number of requests per second")
+
+// RateLimiter is a token bucket that paces requests made through one or more
+// clients. It is safe for concurrent use.
+type RateLimiter struct {
+	// OnWait, if set, is called with the time each request was held back.
+	// Set it before the limiter is shared between goroutines.
+	OnWait func(wait time.Duration)
+
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter allows requestsPerSecond on average with bursts of up to
+// burst requests. For a per-minute ceiling use NewRateLimiter(perMinute/60.0, n).
+// It returns ErrInvalidRateLimit unless requestsPerSecond is positive and finite.
+func NewRateLimiter(requestsPerSecond float64, burst int) (*RateLimiter, error) {
+	if requestsPerSecond <= 0 || math.IsInf(requestsPerSecond, 0) || math.IsNaN(requestsPerSecond) {
+		return nil, ErrInvalidRateLimit
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}, nil
+}
+
+// WithRateLimit paces the client's requests with its own token bucket
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(g *Geocodio) error {
+		limiter, err := NewRateLimiter(requestsPerSecond, burst)
+		if err != nil {
+			return err
+		}
+		g.rateLimiter = limiter
+		return nil
+	}
+}
+
+// WithRateLimiter paces the client's requests with a limiter that can be
+// shared with other clients using the same API key
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(g *Geocodio) error {
+		if limiter == nil || limiter.rate <= 0 {
+			return errors.New("Rate limiter must allow a positive request rate")
+		}
+		g.rateLimiter = limiter
+		return nil
+	}
+}
+
+// Wait blocks until a request may be made and returns how long it waited.
+// It fails fast with ErrRateLimitWaitExceedsDeadline when ctx would expire
+// first, and returns ctx.Err() if ctx is cancelled while waiting.
+func (l *RateLimiter) Wait(ctx context.Context) (time.Duration, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	wait, ok := l.reserve(ctx)
+	if !ok {
+		return 0, ErrRateLimitWaitExceedsDeadline
+	}
+
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			l.release()
+			return 0, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if l.OnWait != nil {
+		l.OnWait(wait)
+	}
+
+	return wait, nil
+}
+
+// reserve takes a token, possibly from the future, and returns how long the
+// caller has to wait for it
+func (l *RateLimiter) reserve(ctx context.Context) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+
+	var wait time.Duration
+	if l.tokens < 1 {
+		wait = time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && wait > 0 && now.Add(wait).After(deadline) {
+		return 0, false
+	}
+
+	l.tokens--
+	return wait, true
+}
+
+// release returns a reserved token that was not used
+func (l *RateLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = math.Min(l.burst, l.tokens+1)
+}
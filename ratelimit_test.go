@@ -0,0 +1,122 @@
+package geocodio_test
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/strategycomplex/go-geocodio"
+)
+
+func TestRateLimiterWait(t *testing.T) {
+	limiter, err := geocodio.NewRateLimiter(20, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wait, err := limiter.Wait(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wait != 0 {
+		t.Error("Expected the first request not to wait but waited", wait)
+	}
+
+	wait, err = limiter.Wait(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wait < 30*time.Millisecond || wait > 60*time.Millisecond {
+		t.Error("Expected to wait about 50ms but waited", wait)
+	}
+}
+
+func TestRateLimiterFailsFast(t *testing.T) {
+	limiter, err := geocodio.NewRateLimiter(1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := limiter.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = limiter.Wait(ctx)
+	if err != geocodio.ErrRateLimitWaitExceedsDeadline {
+		t.Error("Expected error", geocodio.ErrRateLimitWaitExceedsDeadline, "but saw", err)
+	}
+	if time.Since(start) > 5*time.Millisecond {
+		t.Error("Expected to fail without waiting")
+	}
+}
+
+func TestRateLimiterSharedAcrossGoroutines(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(optionsTestResponse))
+	}))
+	defer server.Close()
+
+	var (
+		mu     sync.Mutex
+		waited int
+	)
+	limiter, err := geocodio.NewRateLimiter(100, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	limiter.OnWait = func(wait time.Duration) {
+		if wait > 0 {
+			mu.Lock()
+			waited++
+			mu.Unlock()
+		}
+	}
+
+	gc, err := geocodio.NewWithOptions(
+		geocodio.WithAPIKey("test-key"),
+		geocodio.WithBaseURL(server.URL),
+		geocodio.WithRateLimiter(limiter),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := gc.Geocode(AddressTestOneFull); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 2 requests from the burst, 8 more at 100/s
+	if elapsed := time.Since(start); elapsed < 70*time.Millisecond {
+		t.Error("Expected requests to be paced but they took", elapsed)
+	}
+	if waited != 8 {
+		t.Error("Expected 8 requests to wait but saw", waited)
+	}
+}
+
+func TestRateLimiterRejectsInvalidRate(t *testing.T) {
+	for _, rate := range []float64{0, -1, math.Inf(1), math.NaN()} {
+		if _, err := geocodio.NewRateLimiter(rate, 1); err != geocodio.ErrInvalidRateLimit {
+			t.Error("Expected error", geocodio.ErrInvalidRateLimit, "for rate", rate, "but saw", err)
+		}
+		if _, err := geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithRateLimit(rate, 1)); err == nil {
+			t.Error("Expected WithRateLimit to reject rate", rate)
+		}
+	}
+}
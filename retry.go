@@ -0,0 +1,122 @@
+package geocodio
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are repeated. Requests are retried
+// on 429 and 5xx responses and on transport errors. Lookups (GET, and POST
+// batches to /geocode, /reverse and /distance-matrix) are always safe to repeat; any other POST
+// is only retried when the API throttled it before processing. When the wait
+// before a retry would outlast the context deadline, the last failure is
+// returned at once: the *APIError of a 429 or 5xx response, or the transport error.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// BaseBackoff is the wait before the first retry, doubled on each attempt
+	BaseBackoff time.Duration
+	// MaxBackoff caps the exponential backoff and the Retry-After wait
+	MaxBackoff time.Duration
+	// Jitter randomly shortens each wait by up to this fraction (0 to 1)
+	Jitter float64
+	// RespectRetryAfter waits for the duration the API asks for in a
+	// Retry-After header instead of the computed backoff
+	RespectRetryAfter bool
+	// OnRetry, if set, is called before waiting for each retry
+	OnRetry func(RetryEvent)
+}
+
+// RetryEvent describes a failed attempt that is about to be retried
+type RetryEvent struct {
+	// Attempt is the number of the attempt that failed, starting at 1
+	Attempt int
+	Method  string
+	// URL is the requested URL with the api_key parameter redacted
+	URL  string
+	Wait time.Duration
+	Err  error
+}
+
+// DefaultRetryPolicy returns a policy suited to long running batch jobs
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:       4,
+		BaseBackoff:       500 * time.Millisecond,
+		MaxBackoff:        30 * time.Second,
+		Jitter:            0.2,
+		RespectRetryAfter: true,
+	}
+}
+
+// WithRetryPolicy enables retries, by default requests are attempted once
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(g *Geocodio) error {
+		if policy.BaseBackoff < 0 || policy.MaxBackoff < 0 {
+			return errors.New("Retry backoff must not be negative")
+		}
+		if policy.Jitter < 0 || policy.Jitter > 1 {
+			return errors.New("Retry jitter must be between 0 and 1")
+		}
+		g.retryPolicy = policy
+		return nil
+	}
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	wait := p.BaseBackoff
+	for i := 1; i < attempt && (p.MaxBackoff == 0 || wait < p.MaxBackoff); i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		wait -= time.Duration(rand.Float64() * p.Jitter * float64(wait))
+	}
+	return wait
+}
+
+// idempotentPosts are POST endpoints that only look data up
+var idempotentPosts = map[string]bool{
+	"/geocode":         true,
+	"/reverse":         true,
+	"/distance-matrix": true,
+}
+
+func isIdempotent(method, path string) bool {
+	if method != MethodPost {
+		return true
+	}
+	for endpoint := range idempotentPosts {
+		if strings.HasSuffix(path, endpoint) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(header http.Header) time.Duration {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
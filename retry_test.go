@@ -0,0 +1,279 @@
+package geocodio_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/strategycomplex/go-geocodio"
+)
+
+func TestRetryOnServerErrorThenSuccess(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"results": [{"query": "` + AddressTestOneFull + `", "response": ` + optionsTestResponse + `}]}`))
+	}))
+	defer server.Close()
+
+	var events []geocodio.RetryEvent
+	gc, err := geocodio.NewWithOptions(
+		geocodio.WithAPIKey("test-key"),
+		geocodio.WithBaseURL(server.URL),
+		geocodio.WithRetryPolicy(geocodio.RetryPolicy{
+			MaxAttempts: 3,
+			BaseBackoff: time.Millisecond,
+			OnRetry: func(event geocodio.RetryEvent) {
+				events = append(events, event)
+			},
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := gc.GeocodeBatch(AddressTestOneFull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Results[0].Query != AddressTestOneFull {
+		t.Error("Unexpected query", result.Results[0].Query)
+	}
+
+	if calls != 3 {
+		t.Error("Expected 3 attempts but saw", calls)
+	}
+	if len(events) != 2 {
+		t.Fatal("Expected 2 retry events but saw", len(events))
+	}
+	if events[0].Attempt != 1 || events[1].Attempt != 2 {
+		t.Error("Unexpected retry attempts", events)
+	}
+	if !errors.Is(events[0].Err, geocodio.ErrServer) {
+		t.Error("Expected retry error", geocodio.ErrServer, "but saw", events[0].Err)
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(optionsTestResponse))
+	}))
+	defer server.Close()
+
+	var wait time.Duration
+	gc, err := geocodio.NewWithOptions(
+		geocodio.WithAPIKey("test-key"),
+		geocodio.WithBaseURL(server.URL),
+		geocodio.WithRetryPolicy(geocodio.RetryPolicy{
+			MaxAttempts:       2,
+			BaseBackoff:       time.Millisecond,
+			RespectRetryAfter: true,
+			OnRetry: func(event geocodio.RetryEvent) {
+				wait = event.Wait
+			},
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = gc.Geocode(AddressTestOneFull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wait != time.Second {
+		t.Error("Expected to wait 1s but waited", wait)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(
+		geocodio.WithAPIKey("test-key"),
+		geocodio.WithBaseURL(server.URL),
+		geocodio.WithRetryPolicy(geocodio.RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = gc.Reverse(AddressTestOneLatitude, AddressTestOneLongitude)
+	if !errors.Is(err, geocodio.ErrServer) {
+		t.Error("Expected error", geocodio.ErrServer, "but saw", err)
+	}
+	if calls != 2 {
+		t.Error("Expected 2 attempts but saw", calls)
+	}
+}
+
+func TestNoRetryOnClientError(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(
+		geocodio.WithAPIKey("test-key"),
+		geocodio.WithBaseURL(server.URL),
+		geocodio.WithRetryPolicy(geocodio.DefaultRetryPolicy()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = gc.Geocode(AddressTestOneFull)
+	if !errors.Is(err, geocodio.ErrUnauthorized) {
+		t.Error("Expected error", geocodio.ErrUnauthorized, "but saw", err)
+	}
+	if calls != 1 {
+		t.Error("Expected 1 attempt but saw", calls)
+	}
+}
+
+func TestRetryTransportErrorRedactsAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	baseURL := server.URL
+	server.Close()
+
+	var events []geocodio.RetryEvent
+	gc, err := geocodio.NewWithOptions(
+		geocodio.WithAPIKey("secret-test-key"),
+		geocodio.WithBaseURL(baseURL),
+		geocodio.WithRetryPolicy(geocodio.RetryPolicy{
+			MaxAttempts: 2,
+			BaseBackoff: time.Millisecond,
+			OnRetry: func(event geocodio.RetryEvent) {
+				events = append(events, event)
+			},
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = gc.Geocode(AddressTestOneFull)
+	if err == nil {
+		t.Fatal("Expected a connection error")
+	}
+	if strings.Contains(err.Error(), "secret-test-key") {
+		t.Error("Expected the API key to be redacted from", err)
+	}
+	if len(events) != 1 || strings.Contains(events[0].Err.Error(), "secret-test-key") {
+		t.Error("Expected the API key to be redacted from retry events", events)
+	}
+}
+
+func TestRetryAfterIsBounded(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1)%2 == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(optionsTestResponse))
+	}))
+	defer server.Close()
+
+	var wait time.Duration
+	gc, err := geocodio.NewWithOptions(
+		geocodio.WithAPIKey("test-key"),
+		geocodio.WithBaseURL(server.URL),
+		geocodio.WithRetryPolicy(geocodio.RetryPolicy{
+			MaxAttempts:       2,
+			BaseBackoff:       time.Millisecond,
+			MaxBackoff:        10 * time.Millisecond,
+			RespectRetryAfter: true,
+			OnRetry: func(event geocodio.RetryEvent) {
+				wait = event.Wait
+			},
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := gc.Geocode(AddressTestOneFull); err != nil {
+		t.Fatal(err)
+	}
+	if wait != 10*time.Millisecond {
+		t.Error("Expected Retry-After to be capped at 10ms but waited", wait)
+	}
+
+	// without a cap the wait would outlast the deadline, so the 429 is returned at once
+	gc, err = geocodio.NewWithOptions(
+		geocodio.WithAPIKey("test-key"),
+		geocodio.WithBaseURL(server.URL),
+		geocodio.WithRetryPolicy(geocodio.RetryPolicy{MaxAttempts: 2, RespectRetryAfter: true}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	_, err = gc.GeocodeContext(ctx, AddressTestOneFull)
+	if !errors.Is(err, geocodio.ErrRateLimited) {
+		t.Error("Expected error", geocodio.ErrRateLimited, "but saw", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Error("Expected to fail fast but took", elapsed)
+	}
+}
+
+func TestRetryGivesUpBeforeDeadline(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error": "Down for maintenance"}`))
+	}))
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(
+		geocodio.WithAPIKey("test-key"),
+		geocodio.WithBaseURL(server.URL),
+		geocodio.WithRetryPolicy(geocodio.RetryPolicy{MaxAttempts: 4, BaseBackoff: time.Hour}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	result, err := gc.ReverseContext(ctx, AddressTestOneLatitude, AddressTestOneLongitude)
+
+	var apiErr *geocodio.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Error("Expected a 503 APIError but saw", err)
+	}
+	if calls != 1 {
+		t.Error("Expected 1 attempt before giving up but saw", calls)
+	}
+	if result.Debug.StatusCode != http.StatusServiceUnavailable || !strings.Contains(result.ResponseAsString(), "maintenance") {
+		t.Error("Expected the failed response to be recorded but saw", result.Debug.Status, result.ResponseAsString())
+	}
+}
@@ -1,7 +1,7 @@
 package geocodio
 
 import (
-	"fmt"
+	"context"
 	"strconv"
 	"strings"
 )
@@ -11,6 +11,11 @@ import (
 */
 // Reverse does a reverse geocode look up for a single coordinate
 func (g *Geocodio) Reverse(latitude, longitude float64) (GeocodeResult, error) {
+	return g.ReverseContext(context.Background(), latitude, longitude)
+}
+
+// ReverseContext is like Reverse but aborts the request when ctx is cancelled
+func (g *Geocodio) ReverseContext(ctx context.Context, latitude, longitude float64) (GeocodeResult, error) {
 	// if there is an address here, they should probably think about moving
 	// regardless, we'll consider it an error
 	if latitude == 0.0 && longitude == 0.0 {
@@ -21,7 +26,7 @@ func (g *Geocodio) Reverse(latitude, longitude float64) (GeocodeResult, error) {
 	lngStr := strconv.FormatFloat(longitude, 'f', 9, 64)
 
 	resp := GeocodeResult{}
-	err := g.get("/reverse", map[string]string{"q": latStr + "," + lngStr}, &resp)
+	err := g.lookup(ctx, "/reverse", map[string]string{"q": latStr + "," + lngStr}, &resp)
 	if err != nil {
 		return resp, err
 	}
@@ -35,27 +40,106 @@ func (g *Geocodio) Reverse(latitude, longitude float64) (GeocodeResult, error) {
 
 // GeocodeAndReturnTimezone will geocode and include Timezone in the fields response
 func (g *Geocodio) ReverseAndReturnTimezone(latitude, longitude float64) (GeocodeResult, error) {
-	return g.ReverseReturnFields(latitude, longitude, "timezone")
+	return g.ReverseWithFields(latitude, longitude, NewFieldSet(FieldTimezone))
+}
+
+// ReverseAndReturnTimezoneContext is like ReverseAndReturnTimezone but honors ctx
+func (g *Geocodio) ReverseAndReturnTimezoneContext(ctx context.Context, latitude, longitude float64) (GeocodeResult, error) {
+	return g.ReverseWithFieldsContext(ctx, latitude, longitude, NewFieldSet(FieldTimezone))
 }
 
 // GeocodeAndReturnZip4 will geocode and include zip4 in the fields response
 func (g *Geocodio) ReverseAndReturnZip4(latitude, longitude float64) (GeocodeResult, error) {
-	return g.ReverseReturnFields(latitude, longitude, "zip4")
+	return g.ReverseWithFields(latitude, longitude, NewFieldSet(FieldZip4))
+}
+
+// ReverseAndReturnZip4Context is like ReverseAndReturnZip4 but honors ctx
+func (g *Geocodio) ReverseAndReturnZip4Context(ctx context.Context, latitude, longitude float64) (GeocodeResult, error) {
+	return g.ReverseWithFieldsContext(ctx, latitude, longitude, NewFieldSet(FieldZip4))
 }
 
 // GeocodeAndReturnCongressionalDistrict will geocode and include Congressional District in the fields response
 func (g *Geocodio) ReverseAndReturnCongressionalDistrict(latitude, longitude float64) (GeocodeResult, error) {
-	return g.ReverseReturnFields(latitude, longitude, "cd")
+	return g.ReverseWithFields(latitude, longitude, NewFieldSet(FieldCongressionalDistrict))
+}
+
+// ReverseAndReturnCongressionalDistrictContext is like ReverseAndReturnCongressionalDistrict but honors ctx
+func (g *Geocodio) ReverseAndReturnCongressionalDistrictContext(ctx context.Context, latitude, longitude float64) (GeocodeResult, error) {
+	return g.ReverseWithFieldsContext(ctx, latitude, longitude, NewFieldSet(FieldCongressionalDistrict))
 }
 
 // GeocodeAndReturnStateLegislativeDistricts will geocode and include State Legislative Districts in the fields response
 func (g *Geocodio) ReverseAndReturnStateLegislativeDistricts(latitude, longitude float64) (GeocodeResult, error) {
-	return g.ReverseReturnFields(latitude, longitude, "stateleg")
+	return g.ReverseWithFields(latitude, longitude, NewFieldSet(FieldStateLegislativeDistricts))
+}
+
+// ReverseAndReturnStateLegislativeDistrictsContext is like ReverseAndReturnStateLegislativeDistricts but honors ctx
+func (g *Geocodio) ReverseAndReturnStateLegislativeDistrictsContext(ctx context.Context, latitude, longitude float64) (GeocodeResult, error) {
+	return g.ReverseWithFieldsContext(ctx, latitude, longitude, NewFieldSet(FieldStateLegislativeDistricts))
 }
 
 // GeocodeAndReturnCongressAndStateDistricts will geocode and include Congressional District and State Legislative Districts in the fields response
 func (g *Geocodio) ReverseAndReturnCongressAndStateDistricts(latitude, longitude float64) (GeocodeResult, error) {
-	return g.ReverseReturnFields(latitude, longitude, "cd,stateleg")
+	return g.ReverseWithFields(latitude, longitude, NewFieldSet(FieldCongressionalDistrict, FieldStateLegislativeDistricts))
+}
+
+// ReverseAndReturnCongressAndStateDistrictsContext is like ReverseAndReturnCongressAndStateDistricts but honors ctx
+func (g *Geocodio) ReverseAndReturnCongressAndStateDistrictsContext(ctx context.Context, latitude, longitude float64) (GeocodeResult, error) {
+	return g.ReverseWithFieldsContext(ctx, latitude, longitude, NewFieldSet(FieldCongressionalDistrict, FieldStateLegislativeDistricts))
+}
+
+// ReverseAndReturnRiding will reverse geocode and include the Canadian federal Riding in the fields response
+func (g *Geocodio) ReverseAndReturnRiding(latitude, longitude float64) (GeocodeResult, error) {
+	return g.ReverseWithFields(latitude, longitude, NewFieldSet(FieldRiding))
+}
+
+// ReverseAndReturnRidingContext is like ReverseAndReturnRiding but honors ctx
+func (g *Geocodio) ReverseAndReturnRidingContext(ctx context.Context, latitude, longitude float64) (GeocodeResult, error) {
+	return g.ReverseWithFieldsContext(ctx, latitude, longitude, NewFieldSet(FieldRiding))
+}
+
+// ReverseAndReturnProvincialRiding will reverse geocode and include the Canadian Provincial Riding in the fields response
+func (g *Geocodio) ReverseAndReturnProvincialRiding(latitude, longitude float64) (GeocodeResult, error) {
+	return g.ReverseWithFields(latitude, longitude, NewFieldSet(FieldProvincialRiding))
+}
+
+// ReverseAndReturnProvincialRidingContext is like ReverseAndReturnProvincialRiding but honors ctx
+func (g *Geocodio) ReverseAndReturnProvincialRidingContext(ctx context.Context, latitude, longitude float64) (GeocodeResult, error) {
+	return g.ReverseWithFieldsContext(ctx, latitude, longitude, NewFieldSet(FieldProvincialRiding))
+}
+
+// ReverseAndReturnFederalAndProvincialRidings will reverse geocode and include the Riding and Provincial Riding in the fields response
+func (g *Geocodio) ReverseAndReturnFederalAndProvincialRidings(latitude, longitude float64) (GeocodeResult, error) {
+	return g.ReverseWithFields(latitude, longitude, NewFieldSet(FieldRiding, FieldProvincialRiding))
+}
+
+// ReverseAndReturnFederalAndProvincialRidingsContext is like ReverseAndReturnFederalAndProvincialRidings but honors ctx
+func (g *Geocodio) ReverseAndReturnFederalAndProvincialRidingsContext(ctx context.Context, latitude, longitude float64) (GeocodeResult, error) {
+	return g.ReverseWithFieldsContext(ctx, latitude, longitude, NewFieldSet(FieldRiding, FieldProvincialRiding))
+}
+
+// ReverseAndReturnStatCan will reverse geocode and include Statistics Canada geographies in the fields response
+func (g *Geocodio) ReverseAndReturnStatCan(latitude, longitude float64) (GeocodeResult, error) {
+	return g.ReverseWithFields(latitude, longitude, NewFieldSet(FieldStatCan))
+}
+
+// ReverseAndReturnStatCanContext is like ReverseAndReturnStatCan but honors ctx
+func (g *Geocodio) ReverseAndReturnStatCanContext(ctx context.Context, latitude, longitude float64) (GeocodeResult, error) {
+	return g.ReverseWithFieldsContext(ctx, latitude, longitude, NewFieldSet(FieldStatCan))
+}
+
+// ReverseWithFields will reverse geocode and include the given fields in the
+// response. Unknown fields are rejected before any request is made.
+func (g *Geocodio) ReverseWithFields(latitude, longitude float64, fields FieldSet) (GeocodeResult, error) {
+	return g.ReverseWithFieldsContext(context.Background(), latitude, longitude, fields)
+}
+
+// ReverseWithFieldsContext is like ReverseWithFields but aborts the request when ctx is cancelled
+func (g *Geocodio) ReverseWithFieldsContext(ctx context.Context, latitude, longitude float64, fields FieldSet) (GeocodeResult, error) {
+	if err := fields.Validate(); err != nil {
+		return GeocodeResult{}, err
+	}
+	return g.ReverseReturnFieldsContext(ctx, latitude, longitude, fields.Strings()...)
 }
 
 // GeocodeReturnFields will geocode and includes additional fields in response
@@ -65,6 +149,11 @@ func (g *Geocodio) ReverseAndReturnCongressAndStateDistricts(latitude, longitude
 		Each field counts as an additional lookup each
 */
 func (g *Geocodio) ReverseReturnFields(latitude, longitude float64, fields ...string) (GeocodeResult, error) {
+	return g.ReverseReturnFieldsContext(context.Background(), latitude, longitude, fields...)
+}
+
+// ReverseReturnFieldsContext is like ReverseReturnFields but aborts the request when ctx is cancelled
+func (g *Geocodio) ReverseReturnFieldsContext(ctx context.Context, latitude, longitude float64, fields ...string) (GeocodeResult, error) {
 	// if there is an address here, they should probably think about moving
 	// regardless, we'll consider it an error
 	if latitude == 0.0 && longitude == 0.0 {
@@ -74,10 +163,14 @@ func (g *Geocodio) ReverseReturnFields(latitude, longitude float64, fields ...st
 	latStr := strconv.FormatFloat(latitude, 'f', 9, 64)
 	lngStr := strconv.FormatFloat(longitude, 'f', 9, 64)
 
+	if err := g.checkFieldVersions(fieldSetFromNames(fields)); err != nil {
+		return GeocodeResult{}, err
+	}
+
 	fieldsCommaSeparated := strings.Join(fields, ",")
 	resp := GeocodeResult{}
 
-	err := g.get("/reverse",
+	err := g.lookup(ctx, "/reverse",
 		map[string]string{
 			"q":      latStr + "," + lngStr,
 			"fields": fieldsCommaSeparated,
@@ -96,6 +189,22 @@ func (g *Geocodio) ReverseReturnFields(latitude, longitude float64, fields ...st
 
 // ReverseBatch supports a batch lookup by lat/lng coordinate pairs
 func (g *Geocodio) ReverseBatch(latlngs ...float64) (BatchResponse, error) {
+	return g.ReverseBatchContext(context.Background(), latlngs...)
+}
+
+// ReverseBatchContext is like ReverseBatch but aborts the request when ctx is cancelled
+func (g *Geocodio) ReverseBatchContext(ctx context.Context, latlngs ...float64) (BatchResponse, error) {
+	return g.ReverseBatchWithOptionsContext(ctx, BatchOptions{}, latlngs...)
+}
+
+// ReverseBatchWithOptions supports a batch lookup by lat/lng coordinate pairs,
+// requesting fields and limiting the results of every query
+func (g *Geocodio) ReverseBatchWithOptions(opts BatchOptions, latlngs ...float64) (BatchResponse, error) {
+	return g.ReverseBatchWithOptionsContext(context.Background(), opts, latlngs...)
+}
+
+// ReverseBatchWithOptionsContext is like ReverseBatchWithOptions but aborts the request when ctx is cancelled
+func (g *Geocodio) ReverseBatchWithOptionsContext(ctx context.Context, opts BatchOptions, latlngs ...float64) (BatchResponse, error) {
 	resp := BatchResponse{}
 	if len(latlngs) == 0 {
 		return resp, ErrReverseBatchMissingCoords
@@ -105,26 +214,17 @@ func (g *Geocodio) ReverseBatch(latlngs ...float64) (BatchResponse, error) {
 		return resp, ErrReverseBatchInvalidCoordsPairs
 	}
 
-	var (
-		payload = []string{}
-		pair    string
-	)
+	payload := make([]string, 0, len(latlngs)/2)
+	for i := 0; i < len(latlngs); i += 2 {
+		payload = append(payload, Location{Latitude: latlngs[i], Longitude: latlngs[i+1]}.String())
+	}
 
-	for i := range latlngs {
-		coord := strconv.FormatFloat(latlngs[i], 'f', 9, 64)
-		if i == 0 {
-			pair = coord
-			continue
-		}
-		if i%2 == 0 {
-			pair = fmt.Sprintf("%s,%s", pair, coord)
-			payload = append(payload, pair)
-			continue
-		}
-		pair = coord
+	query, err := g.batchQuery(opts)
+	if err != nil {
+		return resp, err
 	}
 
-	err := g.post("/reverse", payload, nil, &resp)
+	err = g.batchLookup(ctx, "/reverse", payload, query, &resp)
 	if err != nil {
 		return resp, err
 	}
@@ -136,3 +236,45 @@ func (g *Geocodio) ReverseBatch(latlngs ...float64) (BatchResponse, error) {
 	return resp, nil
 
 }
+
+// ReverseBatchMap reverse geocodes locations keyed by caller IDs and returns
+// each result under the same key
+func (g *Geocodio) ReverseBatchMap(locations map[string]Location) (map[string]BatchResultItem, error) {
+	return g.ReverseBatchMapContext(context.Background(), locations)
+}
+
+// ReverseBatchMapContext is like ReverseBatchMap but aborts the request when ctx is cancelled
+func (g *Geocodio) ReverseBatchMapContext(ctx context.Context, locations map[string]Location) (map[string]BatchResultItem, error) {
+	return g.reverseBatchMap(ctx, BatchOptions{}, locations)
+}
+
+func (g *Geocodio) reverseBatchMap(ctx context.Context, opts BatchOptions, locations map[string]Location) (map[string]BatchResultItem, error) {
+	if len(locations) == 0 {
+		return nil, ErrReverseBatchMissingCoords
+	}
+
+	payload := make(map[string]string, len(locations))
+	for key, location := range locations {
+		if location.Latitude == 0.0 && location.Longitude == 0.0 {
+			return nil, ErrReverseGecodeMissingLatLng
+		}
+		payload[key] = location.String()
+	}
+
+	query, err := g.batchQuery(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := batchMapResponse{}
+	err = g.post(ctx, "/reverse", payload, query, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Results) == 0 {
+		return nil, ErrNoResultsFound
+	}
+
+	return resp.items(), nil
+}
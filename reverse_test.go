@@ -1,6 +1,8 @@
 package geocodio_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -8,6 +10,7 @@ import (
 )
 
 func TestReverseGeocodeLookup(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -37,6 +40,7 @@ func TestReverseGeocodeLookup(t *testing.T) {
 }
 
 func TestReverseWithZeroLatLng(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -48,6 +52,7 @@ func TestReverseWithZeroLatLng(t *testing.T) {
 }
 
 func TestReverseLookupReturningTimezone(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -79,6 +84,7 @@ func TestReverseLookupReturningTimezone(t *testing.T) {
 }
 
 func TestReverseLookupReturningZip4(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -111,6 +117,7 @@ func TestReverseLookupReturningZip4(t *testing.T) {
 }
 
 func TestReverseLookupReturningCongressionalDistrict(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -153,6 +160,7 @@ func TestReverseLookupReturningCongressionalDistrict(t *testing.T) {
 }
 
 func TestReverseLookupReturningStateLegislativeDistricts(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -193,6 +201,7 @@ func TestReverseLookupReturningStateLegislativeDistricts(t *testing.T) {
 }
 
 func TestReverseLookupReturningMultipleFields(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -244,6 +253,7 @@ func TestReverseLookupReturningMultipleFields(t *testing.T) {
 }
 
 func TestReverseBatchLookup(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -266,6 +276,7 @@ func TestReverseBatchLookup(t *testing.T) {
 }
 
 func TestReverseBatchWithoutLatLng(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -277,6 +288,7 @@ func TestReverseBatchWithoutLatLng(t *testing.T) {
 }
 
 func TestReverseBatchWithInvalidLatLngPairs(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -294,6 +306,7 @@ func TestReverseBatchWithInvalidLatLngPairs(t *testing.T) {
 }
 
 func TestReverseWithInvalidLatLng(t *testing.T) {
+	skipWithoutAPIKey(t)
 	gc, err := geocodio.New()
 	if err != nil {
 		t.Error("Failed with API KEY set.", err)
@@ -318,3 +331,23 @@ func TestReverseWithInvalidLatLng(t *testing.T) {
 	}
 
 }
+
+func TestReverseContextCancelled(t *testing.T) {
+	gc, err := geocodio.New("test-key")
+	if err != nil {
+		t.Error("Failed with API KEY set.", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = gc.ReverseContext(ctx, AddressTestOneLatitude, AddressTestOneLongitude)
+	if !errors.Is(err, context.Canceled) {
+		t.Error("Expected error", context.Canceled, "but saw", err)
+	}
+
+	_, err = gc.ReverseBatchContext(ctx, AddressTestOneLatitude, AddressTestOneLongitude)
+	if !errors.Is(err, context.Canceled) {
+		t.Error("Expected error", context.Canceled, "but saw", err)
+	}
+}
@@ -0,0 +1,241 @@
+package geocodio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultStreamBatchSize is the number of queries a stream sends per batch request
+	DefaultStreamBatchSize = 100
+	// DefaultStreamWindow is how long a stream waits to fill a batch before sending it
+	DefaultStreamWindow = 500 * time.Millisecond
+)
+
+// StreamQuery is a query sent through a stream, Key is returned with its result
+type StreamQuery struct {
+	Key     string
+	Address string
+	// Location is reverse geocoded instead of Address when StreamOptions.Reverse is set
+	Location Location
+}
+
+// StreamResult is the outcome of a StreamQuery. Err is set when the query
+// had no results, including when its whole batch failed.
+type StreamResult struct {
+	Query    StreamQuery
+	Response BatchResultItem
+	Err      error
+}
+
+// StreamOptions configure a stream
+type StreamOptions struct {
+	// BatchOptions are applied to every query
+	BatchOptions
+	// Reverse geocodes each query's Location instead of its Address
+	Reverse bool
+	// BatchSize is the most queries sent per request, DefaultStreamBatchSize
+	// if 0 and at most MaxBatchSize
+	BatchSize int
+	// Window is the longest a query waits for its batch to fill before it is
+	// sent anyway, DefaultStreamWindow if 0
+	Window time.Duration
+	// Concurrency is the number of batch requests in flight, 1 if 0
+	Concurrency int
+	// Buffer is the capacity of the result channel, 0 if negative
+	Buffer int
+}
+
+func (o StreamOptions) batchSize() int {
+	switch {
+	case o.BatchSize <= 0:
+		return DefaultStreamBatchSize
+	case o.BatchSize > MaxBatchSize:
+		return MaxBatchSize
+	}
+	return o.BatchSize
+}
+
+func (o StreamOptions) window() time.Duration {
+	if o.Window <= 0 {
+		return DefaultStreamWindow
+	}
+	return o.Window
+}
+
+func (o StreamOptions) concurrency() int {
+	if o.Concurrency <= 0 {
+		return 1
+	}
+	return o.Concurrency
+}
+
+func (o StreamOptions) buffer() int {
+	if o.Buffer < 0 {
+		return 0
+	}
+	return o.Buffer
+}
+
+// Stream geocodes queries as they arrive on in, grouping them into batch
+// requests by size and time window, and emits a result for each query on the
+// returned channel. Results of a batch are emitted in order, but batches may
+// complete out of order when Concurrency is above 1.
+//
+// Reading from in pauses while the result channel is full and all requests
+// are in flight. Once in is closed the remaining queries are sent, their
+// results emitted and the result channel closed.
+func (g *Geocodio) Stream(in <-chan StreamQuery, opts StreamOptions) <-chan StreamResult {
+	return g.StreamContext(context.Background(), in, opts)
+}
+
+// StreamContext is like Stream but stops when ctx is cancelled, results not
+// yet emitted are dropped and the result channel is closed
+func (g *Geocodio) StreamContext(ctx context.Context, in <-chan StreamQuery, opts StreamOptions) <-chan StreamResult {
+	out := make(chan StreamResult, opts.buffer())
+	batches := make(chan []StreamQuery)
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.concurrency(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				g.streamBatch(ctx, opts, batch, out)
+			}
+		}()
+	}
+
+	go func() {
+		defer func() {
+			close(batches)
+			wg.Wait()
+			close(out)
+		}()
+		collectStream(ctx, in, opts, batches)
+	}()
+
+	return out
+}
+
+// collectStream groups queries from in into batches until in is closed or ctx is cancelled
+func collectStream(ctx context.Context, in <-chan StreamQuery, opts StreamOptions, batches chan<- []StreamQuery) {
+	var (
+		batch  []StreamQuery
+		timer  *time.Timer
+		window <-chan time.Time
+	)
+
+	send := func() bool {
+		if timer != nil {
+			timer.Stop()
+			timer, window = nil, nil
+		}
+		if len(batch) == 0 {
+			return true
+		}
+		select {
+		case batches <- batch:
+			batch = nil
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for {
+		select {
+		case query, ok := <-in:
+			if !ok {
+				send()
+				return
+			}
+			batch = append(batch, query)
+			if len(batch) == 1 {
+				timer = time.NewTimer(opts.window())
+				window = timer.C
+			}
+			if len(batch) >= opts.batchSize() && !send() {
+				return
+			}
+
+		case <-window:
+			timer, window = nil, nil
+			if !send() {
+				return
+			}
+
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		}
+	}
+}
+
+// streamBatch looks up a batch and emits a result for each of its queries
+func (g *Geocodio) streamBatch(ctx context.Context, opts StreamOptions, batch []StreamQuery, out chan<- StreamResult) {
+	results := make([]StreamResult, len(batch))
+
+	// queries that can't be looked up fail on their own instead of failing the batch
+	var (
+		indexes   []int
+		addresses []string
+		latlngs   []float64
+	)
+	for i, query := range batch {
+		results[i].Query = query
+		switch {
+		case opts.Reverse && query.Location == (Location{}):
+			results[i].Err = ErrReverseGecodeMissingLatLng
+		case !opts.Reverse && query.Address == "":
+			results[i].Err = ErrAddressIsEmpty
+		default:
+			indexes = append(indexes, i)
+			addresses = append(addresses, query.Address)
+			latlngs = append(latlngs, query.Location.Latitude, query.Location.Longitude)
+		}
+	}
+
+	if len(indexes) > 0 {
+		var (
+			resp BatchResponse
+			err  error
+		)
+		if opts.Reverse {
+			resp, err = g.ReverseBatchWithOptionsContext(ctx, opts.BatchOptions, latlngs...)
+		} else {
+			resp, err = g.GeocodeBatchWithOptionsContext(ctx, opts.BatchOptions, addresses...)
+		}
+		if err == nil && len(resp.Results) != len(indexes) {
+			err = fmt.Errorf("expected %d results but received %d", len(indexes), len(resp.Results))
+		}
+
+		for j, i := range indexes {
+			switch {
+			case err != nil:
+				results[i].Err = err
+			case resp.Results[j].Response.Error != "":
+				results[i].Response = resp.Results[j].Response
+				results[i].Err = errors.New(resp.Results[j].Response.Error)
+			case len(resp.Results[j].Response.Results) == 0:
+				results[i].Response = resp.Results[j].Response
+				results[i].Err = ErrNoResultsFound
+			default:
+				results[i].Response = resp.Results[j].Response
+			}
+		}
+	}
+
+	for _, result := range results {
+		select {
+		case out <- result:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
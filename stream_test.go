@@ -0,0 +1,146 @@
+package geocodio_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/strategycomplex/go-geocodio"
+	"github.com/strategycomplex/go-geocodio/geocodiotest"
+)
+
+func newStreamServer(t *testing.T) (*geocodiotest.Server, *geocodio.Geocodio) {
+	server, gc := geocodiotest.NewServerT(t)
+	for i := 0; i < 10; i++ {
+		server.HandleGeocode("address "+strconv.Itoa(i), geocodio.Address{Formatted: "result " + strconv.Itoa(i)})
+	}
+	return server, gc
+}
+
+func TestStreamBatchesBySize(t *testing.T) {
+	server, gc := newStreamServer(t)
+
+	in := make(chan geocodio.StreamQuery)
+	out := gc.Stream(in, geocodio.StreamOptions{BatchSize: 2, Window: time.Hour})
+
+	go func() {
+		for i := 0; i < 5; i++ {
+			in <- geocodio.StreamQuery{Key: strconv.Itoa(i), Address: "address " + strconv.Itoa(i)}
+		}
+		in <- geocodio.StreamQuery{Key: "empty"}
+		close(in)
+	}()
+
+	results := map[string]geocodio.StreamResult{}
+	for result := range out {
+		results[result.Query.Key] = result
+	}
+
+	if len(results) != 6 {
+		t.Fatal("Expected 6 results but saw", len(results))
+	}
+	for i := 0; i < 5; i++ {
+		result := results[strconv.Itoa(i)]
+		if result.Err != nil || result.Response.Results[0].Formatted != "result "+strconv.Itoa(i) {
+			t.Error("Unexpected result", result)
+		}
+	}
+	if !errors.Is(results["empty"].Err, geocodio.ErrAddressIsEmpty) {
+		t.Error("Expected error", geocodio.ErrAddressIsEmpty, "but saw", results["empty"].Err)
+	}
+
+	// two full batches, and the rest sent when the input was closed
+	if n := len(server.Requests()); n != 3 {
+		t.Error("Expected 3 requests but saw", n)
+	}
+}
+
+func TestStreamSendsPartialBatchAfterWindow(t *testing.T) {
+	_, gc := newStreamServer(t)
+
+	in := make(chan geocodio.StreamQuery)
+	defer close(in)
+	out := gc.Stream(in, geocodio.StreamOptions{BatchSize: 100, Window: 10 * time.Millisecond})
+
+	in <- geocodio.StreamQuery{Key: "a", Address: "address 1"}
+	in <- geocodio.StreamQuery{Key: "b", Address: "nowhere"}
+
+	select {
+	case result := <-out:
+		if result.Query.Key != "a" || result.Err != nil {
+			t.Error("Unexpected result", result)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a result once the window passed")
+	}
+
+	result := <-out
+	if result.Query.Key != "b" || result.Err == nil || result.Err.Error() != geocodiotest.NoResultsError {
+		t.Error("Expected a per-query error", result)
+	}
+}
+
+func TestStreamNegativeBuffer(t *testing.T) {
+	_, gc := newStreamServer(t)
+
+	in := make(chan geocodio.StreamQuery, 1)
+	in <- geocodio.StreamQuery{Key: "a", Address: "address 1"}
+	close(in)
+
+	var results int
+	for result := range gc.Stream(in, geocodio.StreamOptions{Buffer: -1}) {
+		if result.Err != nil {
+			t.Error("Unexpected error", result.Err)
+		}
+		results++
+	}
+	if results != 1 {
+		t.Error("Expected 1 result but saw", results)
+	}
+}
+
+func TestStreamBatchFailure(t *testing.T) {
+	server, gc := newStreamServer(t)
+	server.FailNext(1, 500, "Boom")
+
+	in := make(chan geocodio.StreamQuery, 2)
+	in <- geocodio.StreamQuery{Key: "a", Address: "address 1"}
+	in <- geocodio.StreamQuery{Key: "b", Address: "address 2"}
+	close(in)
+
+	var failed int
+	for result := range gc.Stream(in, geocodio.StreamOptions{}) {
+		if errors.Is(result.Err, geocodio.ErrServer) {
+			failed++
+		}
+	}
+	if failed != 2 {
+		t.Error("Expected both queries to fail but saw", failed)
+	}
+}
+
+func TestStreamCancelled(t *testing.T) {
+	_, gc := newStreamServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan geocodio.StreamQuery)
+	out := gc.StreamContext(ctx, in, geocodio.StreamOptions{})
+
+	in <- geocodio.StreamQuery{Key: "a", Address: "address 1"}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		for range out {
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the results channel to be closed")
+	}
+}
@@ -0,0 +1,132 @@
+package geocodio
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// APIVersion is a Geocodio API version such as "v1.6"
+type APIVersion string
+
+const (
+	APIVersion16 APIVersion = "v1.6"
+	APIVersion17 APIVersion = "v1.7"
+	APIVersion18 APIVersion = "v1.8"
+	APIVersion19 APIVersion = "v1.9"
+
+	// DefaultAPIVersion is used unless WithAPIVersion is provided
+	DefaultAPIVersion = APIVersion16
+
+	// GeocodioAPIHost is the Geocod.io API root, the version is appended to it
+	GeocodioAPIHost = "https://api.geocod.io"
+)
+
+// ErrUnsupportedVersion matches errors for methods or fields that are not
+// available in the client's API version
+var ErrUnsupportedVersion = errors.New("Not supported by API version")
+
+var apiVersionFormat = regexp.MustCompile(`^v?([0-9]+)\.([0-9]+)$`)
+
+// ParseAPIVersion accepts versions such as "1.7" or "v1.7", including versions
+// newer than the constants in this package
+func ParseAPIVersion(version string) (APIVersion, error) {
+	if !apiVersionFormat.MatchString(strings.TrimSpace(version)) {
+		return "", fmt.Errorf("Invalid API version %q, expected a version such as v1.6", version)
+	}
+	return APIVersion("v" + strings.TrimPrefix(strings.TrimSpace(version), "v")), nil
+}
+
+func (v APIVersion) parts() (int, int) {
+	match := apiVersionFormat.FindStringSubmatch(string(v))
+	if match == nil {
+		return 0, 0
+	}
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	return major, minor
+}
+
+// AtLeast reports whether v is the same as or newer than other
+func (v APIVersion) AtLeast(other APIVersion) bool {
+	major, minor := v.parts()
+	otherMajor, otherMinor := other.parts()
+	if major != otherMajor {
+		return major > otherMajor
+	}
+	return minor >= otherMinor
+}
+
+// WithAPIVersion selects the API version, by default DefaultAPIVersion.
+// When a base URL is set with WithBaseURL the version is only used to check
+// which methods and fields are available.
+func WithAPIVersion(version APIVersion) Option {
+	return func(g *Geocodio) error {
+		v, err := ParseAPIVersion(string(version))
+		if err != nil {
+			return err
+		}
+		g.version = v
+		return nil
+	}
+}
+
+// APIVersion returns the API version the client talks to
+func (g *Geocodio) APIVersion() APIVersion {
+	if g.version != "" {
+		return g.version
+	}
+	return DefaultAPIVersion
+}
+
+// VersionError is returned when a method or field requires a newer API
+// version than the client is configured for
+type VersionError struct {
+	Feature  string
+	Required APIVersion
+	Current  APIVersion
+}
+
+func (e *VersionError) Error() string {
+	return fmt.Sprintf("%s requires API %s or newer, the client uses %s", e.Feature, e.Required, e.Current)
+}
+
+// Is allows errors.Is(err, ErrUnsupportedVersion)
+func (e *VersionError) Is(target error) bool {
+	return target == ErrUnsupportedVersion
+}
+
+// featureVersions are the first API versions supporting each method
+var featureVersions = map[string]APIVersion{
+	"Distance":             APIVersion18,
+	"DistanceMatrix":       APIVersion18,
+	"GeocodeWithDistances": APIVersion18,
+}
+
+// fieldVersions are the first API versions supporting each field, fields
+// not listed are available in every supported version
+var fieldVersions = map[Field]APIVersion{
+	FieldStateLegislativeDistrictsNext: APIVersion17,
+}
+
+func (g *Geocodio) requireVersion(feature string) error {
+	required, ok := featureVersions[feature]
+	if !ok || g.APIVersion().AtLeast(required) {
+		return nil
+	}
+	return &VersionError{Feature: feature, Required: required, Current: g.APIVersion()}
+}
+
+// checkFieldVersions returns a VersionError for the first field that the
+// client's API version does not support
+func (g *Geocodio) checkFieldVersions(fields FieldSet) error {
+	for _, field := range fields {
+		required, ok := fieldVersions[field]
+		if ok && !g.APIVersion().AtLeast(required) {
+			return &VersionError{Feature: "Field " + string(field), Required: required, Current: g.APIVersion()}
+		}
+	}
+	return nil
+}
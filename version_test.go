@@ -0,0 +1,94 @@
+package geocodio_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/strategycomplex/go-geocodio"
+)
+
+func TestParseAPIVersion(t *testing.T) {
+	for input, expected := range map[string]geocodio.APIVersion{
+		"1.7":   geocodio.APIVersion17,
+		"v1.9":  geocodio.APIVersion19,
+		"v2.10": "v2.10",
+	} {
+		version, err := geocodio.ParseAPIVersion(input)
+		if err != nil || version != expected {
+			t.Errorf("Expected %q to parse as %s but saw %s, %v", input, expected, version, err)
+		}
+	}
+
+	for _, input := range []string{"", "1", "v1.x", "latest"} {
+		if _, err := geocodio.ParseAPIVersion(input); err == nil {
+			t.Errorf("Expected %q to be invalid", input)
+		}
+	}
+
+	if !geocodio.APIVersion("v2.0").AtLeast(geocodio.APIVersion19) || geocodio.APIVersion16.AtLeast(geocodio.APIVersion17) {
+		t.Error("Unexpected version ordering")
+	}
+	if !geocodio.APIVersion("v1.10").AtLeast(geocodio.APIVersion19) {
+		t.Error("Expected v1.10 to be newer than v1.9")
+	}
+}
+
+func TestWithAPIVersion(t *testing.T) {
+	gc, err := geocodio.New("test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gc.APIVersion() != geocodio.DefaultAPIVersion {
+		t.Error("Expected default API version but saw", gc.APIVersion())
+	}
+
+	gc, err = geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithAPIVersion("1.7"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gc.APIVersion() != geocodio.APIVersion17 {
+		t.Error("Expected v1.7 but saw", gc.APIVersion())
+	}
+
+	_, err = geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithAPIVersion("next"))
+	if err == nil {
+		t.Error("Expected an invalid version error")
+	}
+}
+
+func TestFieldRequiresAPIVersion(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(optionsTestResponse))
+	}))
+	defer server.Close()
+
+	gc, err := geocodio.NewWithOptions(geocodio.WithAPIKey("test-key"), geocodio.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fields := geocodio.NewFieldSet(geocodio.FieldStateLegislativeDistrictsNext)
+
+	_, err = gc.GeocodeWithFields(AddressTestOneFull, fields)
+	if !errors.Is(err, geocodio.ErrUnsupportedVersion) {
+		t.Error("Expected error", geocodio.ErrUnsupportedVersion, "but saw", err)
+	}
+
+	_, err = gc.ReverseReturnFields(AddressTestOneLatitude, AddressTestOneLongitude, "timezone,stateleg-next")
+	if !errors.Is(err, geocodio.ErrUnsupportedVersion) {
+		t.Error("Expected error", geocodio.ErrUnsupportedVersion, "but saw", err)
+	}
+
+	_, err = gc.GeocodeBatchWithOptions(geocodio.BatchOptions{Fields: fields}, AddressTestOneFull)
+	if !errors.Is(err, geocodio.ErrUnsupportedVersion) {
+		t.Error("Expected error", geocodio.ErrUnsupportedVersion, "but saw", err)
+	}
+
+	if calls != 0 {
+		t.Error("Expected no requests but saw", calls)
+	}
+}